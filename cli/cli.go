@@ -0,0 +1,277 @@
+// Package cli implements raptor's command-line subsystem: the subcommands an
+// application's main package wires up to drive a raptor.Migrator.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	raptor "github.com/mrhoseah/raptor/core"
+)
+
+// Config holds the settings the CLI reads from raptor.yaml and the
+// environment: which database to connect to and where migration files
+// live. Concrete wiring of DSN/Driver into an actual *sql.DB and
+// raptor.Schema is left to main, since that's driver-specific.
+type Config struct {
+	DSN           string
+	Driver        string
+	MigrationsDir string
+}
+
+// LoadConfig reads Config from raptor.yaml, if present in the working
+// directory, then overlays RAPTOR_DSN, RAPTOR_DRIVER, and
+// RAPTOR_MIGRATIONS_DIR from the environment, which take precedence over
+// the file. MigrationsDir defaults to "migrations" if neither sets it.
+func LoadConfig() Config {
+	cfg := loadConfigFile("raptor.yaml")
+
+	if v := os.Getenv("RAPTOR_DSN"); v != "" {
+		cfg.DSN = v
+	}
+	if v := os.Getenv("RAPTOR_DRIVER"); v != "" {
+		cfg.Driver = v
+	}
+	if v := os.Getenv("RAPTOR_MIGRATIONS_DIR"); v != "" {
+		cfg.MigrationsDir = v
+	}
+	if cfg.MigrationsDir == "" {
+		cfg.MigrationsDir = "migrations"
+	}
+	return cfg
+}
+
+// loadConfigFile reads the flat "key: value" subset of YAML raptor.yaml
+// needs (dsn, driver, migrations_dir), returning a zero Config if path
+// doesn't exist. raptor deliberately avoids a YAML library dependency for
+// three scalar settings, so nested maps, lists, and multi-line values
+// aren't supported.
+func loadConfigFile(path string) Config {
+	var cfg Config
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		switch key {
+		case "dsn":
+			cfg.DSN = value
+		case "driver":
+			cfg.Driver = value
+		case "migrations_dir":
+			cfg.MigrationsDir = value
+		}
+	}
+	return cfg
+}
+
+const usage = `Available commands:
+  make:migration <name>       Scaffold a new timestamped migration file
+  migrate [--step N]          Apply all (or N) pending migrations
+  rollback [--step N]         Revert the last batch (or the N most recent migrations)
+  migrate:fresh                Drop every table and re-run every migration from scratch
+  migrate:refresh               Revert every migration (via Down) and re-run them all
+  reset                        Revert every applied migration
+  migrate:status [--pending]   Print migration status, or just check for pending ones
+  migrate:validate              Check the database history for unknown or out-of-order migrations`
+
+// Run dispatches args (typically os.Args[1:]) to the matching subcommand and
+// returns the process exit code. mgr may be nil for commands, like
+// make:migration, that don't touch the database.
+func Run(mgr *raptor.Migrator, cfg Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Println(usage)
+		return 1
+	}
+
+	command, rest := args[0], args[1:]
+	switch command {
+	case "make:migration":
+		return runMakeMigration(cfg, rest)
+	case "migrate":
+		return runMigrate(mgr, rest)
+	case "rollback":
+		return runRollback(mgr, rest)
+	case "migrate:fresh":
+		return runSimple(mgr.Fresh)
+	case "migrate:refresh":
+		return runSimple(mgr.Refresh)
+	case "reset":
+		return runSimple(mgr.Reset)
+	case "migrate:status", "status":
+		return runStatus(mgr, rest)
+	case "migrate:validate":
+		return runValidate(mgr)
+	default:
+		fmt.Printf("Unknown command: %s\n%s\n", command, usage)
+		return 1
+	}
+}
+
+func runSimple(fn func() error) int {
+	if err := fn(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func runMigrate(mgr *raptor.Migrator, args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	step := fs.Int("step", 0, "apply at most N pending migrations (0 = all)")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	return runSimple(func() error { return mgr.MigrateSteps(*step) })
+}
+
+func runRollback(mgr *raptor.Migrator, args []string) int {
+	fs := flag.NewFlagSet("rollback", flag.ContinueOnError)
+	step := fs.Int("step", 0, "revert the N most recently applied migrations (0 = just the last batch)")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if *step > 0 {
+		return runSimple(func() error { return mgr.RollbackSteps(*step) })
+	}
+	return runSimple(mgr.Rollback)
+}
+
+func runStatus(mgr *raptor.Migrator, args []string) int {
+	fs := flag.NewFlagSet("migrate:status", flag.ContinueOnError)
+	pending := fs.Bool("pending", false, "exit 1 if any migration is pending, instead of printing the full table")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if *pending {
+		count, err := mgr.PendingCount()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return 1
+		}
+		if count > 0 {
+			fmt.Printf("%d migration(s) pending\n", count)
+			return 1
+		}
+		fmt.Println("No migrations pending")
+		return 0
+	}
+
+	mgr.Status()
+	return 0
+}
+
+func runValidate(mgr *raptor.Migrator) int {
+	report, err := mgr.Validate()
+	if report != nil {
+		fmt.Printf("Pending:     %d\n", len(report.Pending))
+		fmt.Printf("Unknown:     %d %v\n", len(report.Unknown), report.Unknown)
+		fmt.Printf("Out of order: %d %v\n", len(report.OutOfOrder), report.OutOfOrder)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func runMakeMigration(cfg Config, args []string) int {
+	fs := flag.NewFlagSet("make:migration", flag.ContinueOnError)
+	sqlFormat := fs.Bool("sql", false, "scaffold a .sql file instead of a Go struct")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if fs.NArg() != 1 {
+		fmt.Println("Usage: raptor make:migration [--sql] <name>")
+		return 1
+	}
+
+	if err := os.MkdirAll(cfg.MigrationsDir, 0o755); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+
+	timestamp := time.Now().UTC().Format("20060102150405")
+	slug := strings.ReplaceAll(strings.ToLower(fs.Arg(0)), " ", "_")
+
+	var path, content string
+	if *sqlFormat {
+		path = filepath.Join(cfg.MigrationsDir, fmt.Sprintf("%s_%s.sql", timestamp, slug))
+		content = sqlMigrationTemplate
+	} else {
+		path = filepath.Join(cfg.MigrationsDir, fmt.Sprintf("%s_%s.go", timestamp, slug))
+		content = fmt.Sprintf(goMigrationTemplate, exportedName(slug), timestamp+"_"+slug)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Created migration %s\n", path)
+	return 0
+}
+
+// exportedName turns a snake_case slug into an exported Go identifier, e.g.
+// "create_users_table" -> "CreateUsersTable".
+func exportedName(slug string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(slug, "_") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]))
+		b.WriteString(part[1:])
+	}
+	return b.String()
+}
+
+const sqlMigrationTemplate = `-- +raptor Up
+
+
+-- +raptor Down
+
+`
+
+const goMigrationTemplate = `package migrations
+
+import (
+	raptor "github.com/mrhoseah/raptor/core"
+)
+
+// %[1]s was scaffolded by "raptor make:migration".
+type %[1]s struct{}
+
+// Name returns the unique identifier for this migration.
+func (m *%[1]s) Name() string {
+	return "%[2]s"
+}
+
+// Up defines the steps to apply this migration.
+func (m *%[1]s) Up(s raptor.Schema) error {
+	return nil
+}
+
+// Down defines the steps to reverse this migration (rollback).
+func (m *%[1]s) Down(s raptor.Schema) error {
+	return nil
+}
+`
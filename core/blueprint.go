@@ -0,0 +1,198 @@
+package raptor
+
+// ColumnType identifies a column's neutral, driver-agnostic type. Each
+// driver translates it into its own dialect-specific SQL (e.g. SERIAL vs
+// AUTO_INCREMENT vs INTEGER PRIMARY KEY AUTOINCREMENT).
+type ColumnType int
+
+const (
+	// String is a variable-length string column (VARCHAR in most dialects).
+	String ColumnType = iota
+	// Text is an unbounded text column.
+	Text
+	// Integer is a 32-bit signed integer column.
+	Integer
+	// BigInteger is a 64-bit signed integer column.
+	BigInteger
+	// Boolean is a true/false column.
+	Boolean
+	// Timestamp is a date-and-time column.
+	Timestamp
+	// Date is a date-only column.
+	Date
+	// Float is a floating point column.
+	Float
+)
+
+// Column describes a single column inside a Blueprint. Use the chained
+// setters (AutoIncrement, Primary, NotNull, Unique, Default) to refine it,
+// e.g. bp.Integer("id").AutoIncrement().Primary().
+type Column struct {
+	Name   string
+	Type   ColumnType
+	Length int // used by String; 0 means "use the driver's default"
+
+	IsAutoIncrement bool
+	IsPrimary       bool
+	IsNotNull       bool
+	IsUnique        bool
+	HasDefault      bool
+	DefaultValue    string
+}
+
+// AutoIncrement marks the column as auto-incrementing.
+func (c *Column) AutoIncrement() *Column {
+	c.IsAutoIncrement = true
+	return c
+}
+
+// Primary marks the column as the table's primary key. Drivers emit an
+// inline PRIMARY KEY clause on each marked column; composite primary keys
+// are not supported.
+func (c *Column) Primary() *Column {
+	c.IsPrimary = true
+	return c
+}
+
+// NotNull disallows NULL values in the column.
+func (c *Column) NotNull() *Column {
+	c.IsNotNull = true
+	return c
+}
+
+// Unique adds a uniqueness constraint on the column.
+func (c *Column) Unique() *Column {
+	c.IsUnique = true
+	return c
+}
+
+// Default sets the column's default value. value is inserted verbatim into
+// the generated DDL, so pass a valid SQL literal or expression, e.g.
+// Default("CURRENT_TIMESTAMP") or Default("'active'").
+func (c *Column) Default(value string) *Column {
+	c.HasDefault = true
+	c.DefaultValue = value
+	return c
+}
+
+// ForeignKey describes a FOREIGN KEY constraint on a Blueprint, e.g.
+// bp.ForeignKey("user_id").References("users", "id").OnDelete("CASCADE").
+type ForeignKey struct {
+	Column         string
+	RefTable       string
+	RefColumn      string
+	OnDeleteAction string
+	OnUpdateAction string
+}
+
+// References sets the table and column this foreign key points to.
+func (fk *ForeignKey) References(table, column string) *ForeignKey {
+	fk.RefTable = table
+	fk.RefColumn = column
+	return fk
+}
+
+// OnDelete sets the ON DELETE action (e.g. "CASCADE", "SET NULL").
+func (fk *ForeignKey) OnDelete(action string) *ForeignKey {
+	fk.OnDeleteAction = action
+	return fk
+}
+
+// OnUpdate sets the ON UPDATE action (e.g. "CASCADE", "RESTRICT").
+func (fk *ForeignKey) OnUpdate(action string) *ForeignKey {
+	fk.OnUpdateAction = action
+	return fk
+}
+
+// IndexDef describes a (optionally unique) index over one or more columns.
+type IndexDef struct {
+	Name     string
+	Columns  []string
+	IsUnique bool
+}
+
+// Unique marks the index as enforcing uniqueness.
+func (idx *IndexDef) Unique() *IndexDef {
+	idx.IsUnique = true
+	return idx
+}
+
+// Blueprint is the DSL used to describe a table's columns, foreign keys, and
+// indexes. Schema.CreateTable and Schema.AddColumn hand callers a Blueprint
+// to build up instead of a bare []string of column names, so that types,
+// nullability, defaults, keys, and constraints can all be expressed.
+type Blueprint struct {
+	TableName   string
+	Columns     []*Column
+	ForeignKeys []*ForeignKey
+	Indexes     []*IndexDef
+}
+
+// NewBlueprint creates an empty Blueprint for the named table.
+func NewBlueprint(table string) *Blueprint {
+	return &Blueprint{TableName: table}
+}
+
+func (bp *Blueprint) addColumn(name string, t ColumnType) *Column {
+	col := &Column{Name: name, Type: t}
+	bp.Columns = append(bp.Columns, col)
+	return col
+}
+
+// String adds a VARCHAR-like column. length of 0 means "use the driver's
+// default length".
+func (bp *Blueprint) String(name string, length int) *Column {
+	col := bp.addColumn(name, String)
+	col.Length = length
+	return col
+}
+
+// Text adds an unbounded text column.
+func (bp *Blueprint) Text(name string) *Column {
+	return bp.addColumn(name, Text)
+}
+
+// Integer adds a 32-bit integer column.
+func (bp *Blueprint) Integer(name string) *Column {
+	return bp.addColumn(name, Integer)
+}
+
+// BigInteger adds a 64-bit integer column.
+func (bp *Blueprint) BigInteger(name string) *Column {
+	return bp.addColumn(name, BigInteger)
+}
+
+// Boolean adds a true/false column.
+func (bp *Blueprint) Boolean(name string) *Column {
+	return bp.addColumn(name, Boolean)
+}
+
+// Timestamp adds a date-and-time column.
+func (bp *Blueprint) Timestamp(name string) *Column {
+	return bp.addColumn(name, Timestamp)
+}
+
+// Date adds a date-only column.
+func (bp *Blueprint) Date(name string) *Column {
+	return bp.addColumn(name, Date)
+}
+
+// Float adds a floating point column.
+func (bp *Blueprint) Float(name string) *Column {
+	return bp.addColumn(name, Float)
+}
+
+// ForeignKey declares a foreign key on column, to be refined with
+// References/OnDelete/OnUpdate.
+func (bp *Blueprint) ForeignKey(column string) *ForeignKey {
+	fk := &ForeignKey{Column: column}
+	bp.ForeignKeys = append(bp.ForeignKeys, fk)
+	return fk
+}
+
+// Index declares an index over one or more columns, named name.
+func (bp *Blueprint) Index(name string, columns ...string) *IndexDef {
+	idx := &IndexDef{Name: name, Columns: columns}
+	bp.Indexes = append(bp.Indexes, idx)
+	return idx
+}
@@ -0,0 +1,287 @@
+package raptor
+
+import (
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// RawSchema is implemented by drivers whose Schema can also execute raw SQL
+// statements directly, which file-based migrations need since their Up/Down
+// steps aren't expressed through the Blueprint DSL.
+type RawSchema interface {
+	Schema
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// fileMigration is a Migration synthesized from a "-- +raptor Up" / "-- +raptor
+// Down" annotated SQL file.
+type fileMigration struct {
+	name           string
+	upStatements   []string
+	downStatements []string
+}
+
+// Name returns the migration's identifier: its filename without the .sql extension.
+func (m *fileMigration) Name() string {
+	return m.name
+}
+
+// Up executes the file's Up statements against s, which must implement RawSchema.
+func (m *fileMigration) Up(s Schema) error {
+	return execStatements(s, m.upStatements)
+}
+
+// Down executes the file's Down statements against s, which must implement RawSchema.
+func (m *fileMigration) Down(s Schema) error {
+	return execStatements(s, m.downStatements)
+}
+
+func execStatements(s Schema, statements []string) error {
+	exec, ok := s.(RawSchema)
+	if !ok {
+		return fmt.Errorf("schema %T does not support executing raw SQL file migrations", s)
+	}
+	for _, stmt := range statements {
+		if strings.TrimSpace(stmt) == "" {
+			continue
+		}
+		if _, err := exec.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to execute statement %q: %w", stmt, err)
+		}
+	}
+	return nil
+}
+
+// LoadMigrationsFromDir scans dir for "*.sql" files following the
+// "-- +raptor Up" / "-- +raptor Down" convention and returns them as
+// Migrations compatible with NewMigrator.
+func LoadMigrationsFromDir(dir string) ([]Migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory %s: %w", dir, err)
+	}
+
+	migrations := []Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration file %s: %w", entry.Name(), err)
+		}
+		mig, err := newFileMigration(entry.Name(), string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse migration file %s: %w", entry.Name(), err)
+		}
+		migrations = append(migrations, mig)
+	}
+	return migrations, nil
+}
+
+// LoadMigrationsFromFS is LoadMigrationsFromDir for an fs.FS (typically an
+// embed.FS), scanning dir within fsys instead of the host filesystem.
+func LoadMigrationsFromFS(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory %s: %w", dir, err)
+	}
+
+	migrations := []Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration file %s: %w", entry.Name(), err)
+		}
+		mig, err := newFileMigration(entry.Name(), string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse migration file %s: %w", entry.Name(), err)
+		}
+		migrations = append(migrations, mig)
+	}
+	return migrations, nil
+}
+
+func newFileMigration(filename, content string) (*fileMigration, error) {
+	upRaw, downRaw, err := splitUpDownSections(content)
+	if err != nil {
+		return nil, err
+	}
+	return &fileMigration{
+		name:           strings.TrimSuffix(filename, ".sql"),
+		upStatements:   splitMigrationSQL(upRaw),
+		downStatements: splitMigrationSQL(downRaw),
+	}, nil
+}
+
+// splitUpDownSections splits a migration file's content into its
+// "-- +raptor Up" and "-- +raptor Down" sections.
+func splitUpDownSections(content string) (up string, down string, err error) {
+	var upLines, downLines []string
+	section := ""
+	sawUp, sawDown := false, false
+
+	for _, line := range strings.Split(content, "\n") {
+		switch strings.TrimSpace(line) {
+		case "-- +raptor Up":
+			section = "up"
+			sawUp = true
+			continue
+		case "-- +raptor Down":
+			section = "down"
+			sawDown = true
+			continue
+		}
+
+		switch section {
+		case "up":
+			upLines = append(upLines, line)
+		case "down":
+			downLines = append(downLines, line)
+		}
+	}
+
+	if !sawUp {
+		return "", "", fmt.Errorf("missing '-- +raptor Up' annotation")
+	}
+	if !sawDown {
+		return "", "", fmt.Errorf("missing '-- +raptor Down' annotation")
+	}
+	return strings.Join(upLines, "\n"), strings.Join(downLines, "\n"), nil
+}
+
+// splitMigrationSQL splits one section's SQL text into individual
+// statements. Lines between "-- +raptor StatementBegin" and "-- +raptor
+// StatementEnd" are kept as a single statement verbatim (for procedures and
+// triggers containing their own semicolons); everything else is split on
+// semicolon boundaries via splitSQLStatements.
+func splitMigrationSQL(text string) []string {
+	statements := []string{}
+	var buf strings.Builder
+	inBlock := false
+
+	flush := func() {
+		for _, stmt := range splitSQLStatements(buf.String()) {
+			statements = append(statements, stmt)
+		}
+		buf.Reset()
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch trimmed {
+		case "-- +raptor StatementBegin":
+			flush()
+			inBlock = true
+			continue
+		case "-- +raptor StatementEnd":
+			if stmt := strings.TrimSpace(buf.String()); stmt != "" {
+				statements = append(statements, stmt)
+			}
+			buf.Reset()
+			inBlock = false
+			continue
+		}
+		if !inBlock && strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	if !inBlock {
+		flush()
+	}
+	return statements
+}
+
+// splitSQLStatements splits sql on top-level semicolons, treating content
+// inside single/double-quoted strings and $tag$-delimited bodies (as used by
+// PL/pgSQL functions) as opaque so semicolons within them aren't boundaries.
+func splitSQLStatements(sql string) []string {
+	statements := []string{}
+	var cur strings.Builder
+	inSingle, inDouble := false, false
+	dollarTag := ""
+
+	i := 0
+	for i < len(sql) {
+		c := sql[i]
+
+		switch {
+		case dollarTag != "":
+			if strings.HasPrefix(sql[i:], dollarTag) {
+				cur.WriteString(dollarTag)
+				i += len(dollarTag)
+				dollarTag = ""
+				continue
+			}
+		case inSingle:
+			if c == '\'' {
+				inSingle = false
+			}
+		case inDouble:
+			if c == '"' {
+				inDouble = false
+			}
+		case c == '\'':
+			inSingle = true
+		case c == '"':
+			inDouble = true
+		case c == '$':
+			if tag, ok := matchDollarTag(sql[i:]); ok {
+				cur.WriteString(tag)
+				dollarTag = tag
+				i += len(tag)
+				continue
+			}
+		case c == ';':
+			cur.WriteByte(c)
+			if stmt := strings.TrimSpace(cur.String()); stmt != ";" && stmt != "" {
+				statements = append(statements, stmt)
+			}
+			cur.Reset()
+			i++
+			continue
+		}
+
+		cur.WriteByte(c)
+		i++
+	}
+
+	if stmt := strings.TrimSpace(cur.String()); stmt != "" {
+		statements = append(statements, stmt)
+	}
+	return statements
+}
+
+// matchDollarTag reports whether s starts with a $tag$ delimiter (including
+// the bare "$$" form) and returns the matched tag.
+func matchDollarTag(s string) (string, bool) {
+	if len(s) == 0 || s[0] != '$' {
+		return "", false
+	}
+	for j := 1; j < len(s); j++ {
+		if s[j] == '$' {
+			return s[:j+1], true
+		}
+		if !isDollarTagChar(s[j]) {
+			return "", false
+		}
+	}
+	return "", false
+}
+
+func isDollarTagChar(b byte) bool {
+	return b == '_' ||
+		(b >= 'a' && b <= 'z') ||
+		(b >= 'A' && b <= 'Z') ||
+		(b >= '0' && b <= '9')
+}
@@ -1,11 +1,19 @@
 package raptor
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 )
 
+// ErrUnsupported is returned by Schema methods that a driver deliberately
+// does not implement, e.g. the versioned-view operations on every driver
+// other than Postgres.
+var ErrUnsupported = errors.New("raptor: operation not supported by this driver")
+
 // Migration interface defines the contract for all database migrations.
 type Migration interface {
 	Name() string
@@ -13,64 +21,356 @@ type Migration interface {
 	Down(s Schema) error
 }
 
+// VersionedMigration is a two-phase, zero-downtime migration modeled on the
+// expand/contract pattern: Start performs the expand phase (add the new
+// column/table, backfill it, and expose the new shape through a versioned
+// view) while old and new consumers keep running side by side; Complete
+// performs the contract phase (drop the old column/view) once every
+// consumer has moved over; Rollback undoes the expand phase if Complete is
+// never reached.
+type VersionedMigration interface {
+	Name() string
+	Start(s Schema) error
+	Complete(s Schema) error
+	Rollback(s Schema) error
+}
+
+// MigrationRecord represents a single row in the raptor_migrations history table.
+type MigrationRecord struct {
+	ID        int
+	Name      string
+	Batch     int
+	AppliedAt time.Time
+}
+
+// VersionedMigrationState describes the lifecycle state of whichever
+// VersionedMigration is active, as recorded in the history table's state column.
+type VersionedMigrationState struct {
+	Name      string
+	State     string // "in_progress", "complete", or "rolled_back"
+	AppliedAt time.Time
+}
+
 // Schema defines the Domain Specific Language (DSL) for modifying the database structure.
 // This interface is the "plug" point for different database drivers (SQLite, MySQL, Postgres).
 type Schema interface {
-	CreateTable(name string, columns []string) error
+	// CreateTable builds a Blueprint for name via build and executes the
+	// resulting dialect-specific DDL.
+	CreateTable(name string, build func(*Blueprint)) error
 	DropTable(name string) error
-	// Future methods like AddColumn, RenameTable, etc., would go here.
+	// DropAllTables drops every table in the database, not just the ones
+	// raptor created, so Migrator.Fresh can recover from a broken or
+	// partially applied schema that a Down-based Reset/Refresh can't fix.
+	DropAllTables() error
+
+	// AddColumn builds a Blueprint describing the column(s) to add to an
+	// existing table and executes the resulting ALTER TABLE statements.
+	AddColumn(table string, build func(*Blueprint)) error
+	// DropColumn removes a single column from an existing table.
+	DropColumn(table, column string) error
+	// RenameColumn renames a single column on an existing table.
+	RenameColumn(table, oldName, newName string) error
+	// RenameTable renames an existing table.
+	RenameTable(oldName, newName string) error
+	// AddIndex builds a Blueprint describing the index(es) to add to an
+	// existing table and executes the resulting CREATE INDEX statements.
+	AddIndex(table string, build func(*Blueprint)) error
+	// DropIndex removes a named index from an existing table.
+	DropIndex(table, indexName string) error
+
+	// HasMigrationsTable reports whether the raptor_migrations history table
+	// already exists, so the Migrator can create it lazily on first use.
+	HasMigrationsTable() (bool, error)
+	// CreateMigrationsTable creates the raptor_migrations history table
+	// (columns: id, name, batch, applied_at) using dialect-specific DDL.
+	CreateMigrationsTable() error
+	// InsertMigration records that a migration has been applied in a given batch.
+	InsertMigration(name string, batch int) error
+	// DeleteMigration removes a migration's history row, used on rollback.
+	DeleteMigration(name string) error
+	// GetRanMigrations returns every recorded migration, ordered by id.
+	GetRanMigrations() ([]MigrationRecord, error)
+
+	// CreateVersionedView creates a view named "<schema>_<version>" exposing
+	// the columns in mapping (view column name -> underlying expression), so
+	// callers can keep reading the old shape while an expand migration
+	// backfills the new one. Drivers that don't support it return ErrUnsupported.
+	CreateVersionedView(schema, version string, mapping map[string]string) error
+	// DropVersionedView drops the view created by CreateVersionedView for the
+	// given schema/version pair. Drivers that don't support it return ErrUnsupported.
+	DropVersionedView(schema, version string) error
+
+	// BeginVersionedMigration records name as the single in-progress
+	// versioned migration. Drivers that support it must enforce at most one
+	// active migration at a time.
+	BeginVersionedMigration(name string) error
+	// CompleteVersionedMigration marks the active versioned migration
+	// complete, once its contract phase has run.
+	CompleteVersionedMigration(name string) error
+	// RollbackVersionedMigration marks the active versioned migration rolled
+	// back, once its Rollback step has run.
+	RollbackVersionedMigration(name string) error
+	// ActiveVersionedMigration returns the versioned migration currently in
+	// progress, or nil if none is active.
+	ActiveVersionedMigration() (*VersionedMigrationState, error)
+}
+
+// TxSchema is implemented by drivers whose DDL can safely run inside a
+// database transaction. MySQL is the notable opt-out: its DDL statements
+// trigger an implicit commit, so MySQLSchema does not implement TxSchema
+// and the Migrator falls back to its non-transactional path for it.
+type TxSchema interface {
+	Schema
+	// BeginTx starts a transaction that a migration's Up/Down call and its
+	// history-row write will share, so they commit or roll back together.
+	BeginTx() (*sql.Tx, error)
+	// WithTx returns a Schema whose operations run against tx instead of
+	// the driver's own *sql.DB.
+	WithTx(tx *sql.Tx) Schema
+}
+
+// MigrationFailure records a migration that failed to apply or roll back,
+// so operators can see which one broke and why.
+type MigrationFailure struct {
+	Name       string
+	Error      string
+	OccurredAt time.Time
+}
+
+// PlanError indicates that the Migrator's plan can't proceed as recorded:
+// the database's history contains a migration with no corresponding entry
+// in the in-code migrations slice. This typically happens when a teammate's
+// migration was applied and then disappeared from the checkout, e.g. after
+// a bad rebase. Set Migrator.IgnoreUnknown to bypass this check.
+type PlanError struct {
+	Migration string
+	Err       error
+}
+
+func (e *PlanError) Error() string {
+	return fmt.Sprintf("migration %s: %s", e.Migration, e.Err)
+}
+
+func (e *PlanError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationReport summarizes how the in-code migrations slice compares to
+// the database's recorded history.
+type ValidationReport struct {
+	// Pending lists migrations known in code that haven't been applied yet.
+	Pending []string
+	// Unknown lists applied migrations with no corresponding entry in code.
+	Unknown []string
+	// OutOfOrder lists applied migrations whose name sorts earlier than a
+	// migration that was already applied before them, a sign of a rebase
+	// that inserted an older-timestamped migration after the fact.
+	OutOfOrder []string
+}
+
+// HasIssues reports whether the report found anything beyond ordinary
+// pending migrations.
+func (r *ValidationReport) HasIssues() bool {
+	return len(r.Unknown) > 0 || len(r.OutOfOrder) > 0
 }
 
 // --- Simulated Components (for Testing/Demonstration) ---
 
 // SimulatedSchema implements the Schema interface without a real database connection.
-type SimulatedSchema struct{}
+// It keeps its own migration history in memory so it satisfies Schema on its own.
+type SimulatedSchema struct {
+	history         map[string]MigrationRecord
+	nextID          int
+	activeVersioned *VersionedMigrationState
+	tables          map[string]bool
+}
+
+// NewSimulatedSchema creates a new SimulatedSchema instance.
+func NewSimulatedSchema() *SimulatedSchema {
+	return &SimulatedSchema{
+		history: make(map[string]MigrationRecord),
+		tables:  make(map[string]bool),
+	}
+}
+
+// Ensure SimulatedSchema satisfies the Schema interface at compile time.
+var _ Schema = (*SimulatedSchema)(nil)
 
 // CreateTable simulates table creation.
-func (s *SimulatedSchema) CreateTable(name string, columns []string) error {
-	fmt.Printf("  [Simulated Schema] Creating table '%s' with columns: %s\n", name, strings.Join(columns, ", "))
+func (s *SimulatedSchema) CreateTable(name string, build func(*Blueprint)) error {
+	bp := NewBlueprint(name)
+	build(bp)
+
+	names := make([]string, 0, len(bp.Columns))
+	for _, col := range bp.Columns {
+		names = append(names, col.Name)
+	}
+	fmt.Printf("  [Simulated Schema] Creating table '%s' with columns: %s\n", name, strings.Join(names, ", "))
+	s.tables[name] = true
 	return nil
 }
 
 // DropTable simulates table dropping.
 func (s *SimulatedSchema) DropTable(name string) error {
 	fmt.Printf("  [Simulated Schema] Dropping table '%s'\n", name)
+	delete(s.tables, name)
+	return nil
+}
+
+// DropAllTables simulates dropping every table the simulated schema has
+// created, along with its migration history, recovering from whatever shape
+// it was in.
+func (s *SimulatedSchema) DropAllTables() error {
+	names := make([]string, 0, len(s.tables))
+	for name := range s.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Printf("  [Simulated Schema] Dropping all tables: %s\n", strings.Join(names, ", "))
+	s.tables = make(map[string]bool)
+	s.history = make(map[string]MigrationRecord)
 	return nil
 }
 
-// SimulatedDB replaces a real database connection for tracking history.
-type SimulatedDB struct {
-	History   map[string]int // MigrationName -> Batch Number
-	NextBatch int
+// AddColumn simulates adding column(s) to an existing table.
+func (s *SimulatedSchema) AddColumn(table string, build func(*Blueprint)) error {
+	bp := NewBlueprint(table)
+	build(bp)
+
+	names := make([]string, 0, len(bp.Columns))
+	for _, col := range bp.Columns {
+		names = append(names, col.Name)
+	}
+	fmt.Printf("  [Simulated Schema] Adding column(s) %s to '%s'\n", strings.Join(names, ", "), table)
+	return nil
 }
 
-// NewSimulatedDB creates a new SimulatedDB instance.
-func NewSimulatedDB() *SimulatedDB {
-	return &SimulatedDB{
-		History:   make(map[string]int),
-		NextBatch: 1,
+// DropColumn simulates dropping a column from an existing table.
+func (s *SimulatedSchema) DropColumn(table, column string) error {
+	fmt.Printf("  [Simulated Schema] Dropping column '%s' from '%s'\n", column, table)
+	return nil
+}
+
+// RenameColumn simulates renaming a column on an existing table.
+func (s *SimulatedSchema) RenameColumn(table, oldName, newName string) error {
+	fmt.Printf("  [Simulated Schema] Renaming column '%s' to '%s' on '%s'\n", oldName, newName, table)
+	return nil
+}
+
+// RenameTable simulates renaming an existing table.
+func (s *SimulatedSchema) RenameTable(oldName, newName string) error {
+	fmt.Printf("  [Simulated Schema] Renaming table '%s' to '%s'\n", oldName, newName)
+	if s.tables[oldName] {
+		delete(s.tables, oldName)
+		s.tables[newName] = true
 	}
+	return nil
 }
 
-// GetRanMigrations returns a list of migration names that have already been run.
-func (s *SimulatedDB) GetRanMigrations() []string {
-	names := make([]string, 0, len(s.History))
-	for name := range s.History {
-		names = append(names, name)
+// AddIndex simulates adding index(es) to an existing table.
+func (s *SimulatedSchema) AddIndex(table string, build func(*Blueprint)) error {
+	bp := NewBlueprint(table)
+	build(bp)
+
+	names := make([]string, 0, len(bp.Indexes))
+	for _, idx := range bp.Indexes {
+		names = append(names, idx.Name)
 	}
-	sort.Strings(names)
-	return names
+	fmt.Printf("  [Simulated Schema] Adding index(es) %s to '%s'\n", strings.Join(names, ", "), table)
+	return nil
 }
 
-// GetLastBatch returns the highest batch number recorded.
-func (s *SimulatedDB) GetLastBatch() int {
-	maxBatch := 0
-	for _, batch := range s.History {
-		if batch > maxBatch {
-			maxBatch = batch
-		}
+// DropIndex simulates dropping an index from an existing table.
+func (s *SimulatedSchema) DropIndex(table, indexName string) error {
+	fmt.Printf("  [Simulated Schema] Dropping index '%s' from '%s'\n", indexName, table)
+	return nil
+}
+
+// HasMigrationsTable always reports true; the in-memory history map needs no
+// creation step.
+func (s *SimulatedSchema) HasMigrationsTable() (bool, error) {
+	return true, nil
+}
+
+// CreateMigrationsTable is a no-op for the simulated schema.
+func (s *SimulatedSchema) CreateMigrationsTable() error {
+	return nil
+}
+
+// InsertMigration records a migration as applied in the given batch.
+func (s *SimulatedSchema) InsertMigration(name string, batch int) error {
+	s.nextID++
+	s.history[name] = MigrationRecord{
+		ID:        s.nextID,
+		Name:      name,
+		Batch:     batch,
+		AppliedAt: time.Now(),
 	}
-	return maxBatch
+	return nil
+}
+
+// DeleteMigration removes a migration's history entry.
+func (s *SimulatedSchema) DeleteMigration(name string) error {
+	delete(s.history, name)
+	return nil
+}
+
+// GetRanMigrations returns every recorded migration, ordered by id (i.e.
+// application order), matching the Schema contract the real drivers follow.
+func (s *SimulatedSchema) GetRanMigrations() ([]MigrationRecord, error) {
+	records := make([]MigrationRecord, 0, len(s.history))
+	for _, rec := range s.history {
+		records = append(records, rec)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ID < records[j].ID
+	})
+	return records, nil
+}
+
+// CreateVersionedView simulates creating a versioned view over schema.
+func (s *SimulatedSchema) CreateVersionedView(schema, version string, mapping map[string]string) error {
+	fmt.Printf("  [Simulated Schema] Creating versioned view '%s_%s' over '%s' (%d column(s) mapped)\n", schema, version, schema, len(mapping))
+	return nil
+}
+
+// DropVersionedView simulates dropping a versioned view.
+func (s *SimulatedSchema) DropVersionedView(schema, version string) error {
+	fmt.Printf("  [Simulated Schema] Dropping versioned view '%s_%s'\n", schema, version)
+	return nil
+}
+
+// BeginVersionedMigration records name as the in-progress versioned
+// migration, failing if one is already active.
+func (s *SimulatedSchema) BeginVersionedMigration(name string) error {
+	if s.activeVersioned != nil {
+		return fmt.Errorf("migration %s is already in progress", s.activeVersioned.Name)
+	}
+	s.activeVersioned = &VersionedMigrationState{Name: name, State: "in_progress", AppliedAt: time.Now()}
+	return nil
+}
+
+// CompleteVersionedMigration marks the active versioned migration complete.
+func (s *SimulatedSchema) CompleteVersionedMigration(name string) error {
+	if s.activeVersioned == nil || s.activeVersioned.Name != name {
+		return fmt.Errorf("migration %s is not in progress", name)
+	}
+	s.activeVersioned = nil
+	return nil
+}
+
+// RollbackVersionedMigration marks the active versioned migration rolled back.
+func (s *SimulatedSchema) RollbackVersionedMigration(name string) error {
+	if s.activeVersioned == nil || s.activeVersioned.Name != name {
+		return fmt.Errorf("migration %s is not in progress", name)
+	}
+	s.activeVersioned = nil
+	return nil
+}
+
+// ActiveVersionedMigration returns the in-progress versioned migration, if any.
+func (s *SimulatedSchema) ActiveVersionedMigration() (*VersionedMigrationState, error) {
+	return s.activeVersioned, nil
 }
 
 // --- Migrator Core ---
@@ -79,7 +379,28 @@ func (s *SimulatedDB) GetLastBatch() int {
 type Migrator struct {
 	migrations []Migration
 	schema     Schema
-	db         *SimulatedDB // In a real app, this would be a DB interface
+
+	// UseTransaction wraps each migration's Up/Down call and its
+	// history-row write in a single *sql.Tx, so a failure rolls back both
+	// together. It only takes effect when schema implements TxSchema;
+	// drivers that can't run DDL in a transaction (e.g. MySQL) simply
+	// don't implement it, and the Migrator falls back to its plain path.
+	UseTransaction bool
+
+	// Failures records migrations that failed to apply or roll back, most
+	// recent last, so operators can see which one broke and why.
+	Failures []MigrationFailure
+
+	// IgnoreUnknown disables the check that fails Migrate/Rollback when the
+	// database's history contains a migration name with no corresponding
+	// entry in migrations. Leave this false unless you know why the
+	// mismatch exists; it's a safety net against bad rebases.
+	IgnoreUnknown bool
+
+	// versionedMigrations holds every VersionedMigration registered via
+	// RegisterVersioned, keyed by name, so Start/Complete/RollbackActive can
+	// look one up by name without the caller passing it each time.
+	versionedMigrations map[string]VersionedMigration
 }
 
 // NewMigrator creates a new Migrator instance, accepting a concrete Schema implementation.
@@ -93,22 +414,205 @@ func NewMigrator(migrations []Migration, schema Schema) *Migrator {
 	return &Migrator{
 		migrations: migrations,
 		schema:     schema,
-		db:         NewSimulatedDB(), // Assuming we still use the simulated history tracking for simplicity
 	}
 }
 
 // NewSimulatedMigrator creates a Migrator using the SimulatedSchema.
 // This function is now properly capitalized and exported.
 func NewSimulatedMigrator(migrations []Migration) *Migrator {
-	return NewMigrator(migrations, &SimulatedSchema{})
+	return NewMigrator(migrations, NewSimulatedSchema())
+}
+
+// ensureMigrationsTable creates the history table on first use, leaving it
+// untouched on every subsequent call.
+func (m *Migrator) ensureMigrationsTable() error {
+	exists, err := m.schema.HasMigrationsTable()
+	if err != nil {
+		return fmt.Errorf("failed to check for migrations table: %w", err)
+	}
+	if exists {
+		return nil
+	}
+	if err := m.schema.CreateMigrationsTable(); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+	return nil
+}
+
+// recordMigrationFailure appends a failure entry so operators can see which
+// migration broke and why.
+func (m *Migrator) recordMigrationFailure(name string, cause error) {
+	m.Failures = append(m.Failures, MigrationFailure{
+		Name:       name,
+		Error:      cause.Error(),
+		OccurredAt: time.Now(),
+	})
+	fmt.Printf("!! Migration %s failed: %v\n", name, cause)
+}
+
+// applyMigration runs a single migration's Up step and records it in the
+// given batch. When UseTransaction is set and the schema implements
+// TxSchema, both happen inside one *sql.Tx so a failure rolls back the
+// schema change and the history-row insert together.
+func (m *Migrator) applyMigration(mig Migration, batch int) error {
+	txSchema, supportsTx := m.schema.(TxSchema)
+	if !m.UseTransaction || !supportsTx {
+		if err := mig.Up(m.schema); err != nil {
+			wrapped := fmt.Errorf("failed to run migration %s: %w", mig.Name(), err)
+			m.recordMigrationFailure(mig.Name(), wrapped)
+			return wrapped
+		}
+		if err := m.schema.InsertMigration(mig.Name(), batch); err != nil {
+			wrapped := fmt.Errorf("failed to record migration %s: %w", mig.Name(), err)
+			m.recordMigrationFailure(mig.Name(), wrapped)
+			return wrapped
+		}
+		return nil
+	}
+
+	tx, err := txSchema.BeginTx()
+	if err != nil {
+		wrapped := fmt.Errorf("failed to begin transaction for %s: %w", mig.Name(), err)
+		m.recordMigrationFailure(mig.Name(), wrapped)
+		return wrapped
+	}
+	scoped := txSchema.WithTx(tx)
+
+	if err := mig.Up(scoped); err != nil {
+		tx.Rollback()
+		wrapped := fmt.Errorf("failed to run migration %s: %w", mig.Name(), err)
+		m.recordMigrationFailure(mig.Name(), wrapped)
+		return wrapped
+	}
+	if err := scoped.InsertMigration(mig.Name(), batch); err != nil {
+		tx.Rollback()
+		wrapped := fmt.Errorf("failed to record migration %s: %w", mig.Name(), err)
+		m.recordMigrationFailure(mig.Name(), wrapped)
+		return wrapped
+	}
+	if err := tx.Commit(); err != nil {
+		wrapped := fmt.Errorf("failed to commit migration %s: %w", mig.Name(), err)
+		m.recordMigrationFailure(mig.Name(), wrapped)
+		return wrapped
+	}
+	return nil
+}
+
+// revertMigration runs a single migration's Down step and removes its
+// history row, using the same transactional behavior as applyMigration.
+func (m *Migrator) revertMigration(mig Migration) error {
+	txSchema, supportsTx := m.schema.(TxSchema)
+	if !m.UseTransaction || !supportsTx {
+		if err := mig.Down(m.schema); err != nil {
+			wrapped := fmt.Errorf("failed to rollback migration %s: %w", mig.Name(), err)
+			m.recordMigrationFailure(mig.Name(), wrapped)
+			return wrapped
+		}
+		if err := m.schema.DeleteMigration(mig.Name()); err != nil {
+			wrapped := fmt.Errorf("failed to remove migration record %s: %w", mig.Name(), err)
+			m.recordMigrationFailure(mig.Name(), wrapped)
+			return wrapped
+		}
+		return nil
+	}
+
+	tx, err := txSchema.BeginTx()
+	if err != nil {
+		wrapped := fmt.Errorf("failed to begin transaction for %s: %w", mig.Name(), err)
+		m.recordMigrationFailure(mig.Name(), wrapped)
+		return wrapped
+	}
+	scoped := txSchema.WithTx(tx)
+
+	if err := mig.Down(scoped); err != nil {
+		tx.Rollback()
+		wrapped := fmt.Errorf("failed to rollback migration %s: %w", mig.Name(), err)
+		m.recordMigrationFailure(mig.Name(), wrapped)
+		return wrapped
+	}
+	if err := scoped.DeleteMigration(mig.Name()); err != nil {
+		tx.Rollback()
+		wrapped := fmt.Errorf("failed to remove migration record %s: %w", mig.Name(), err)
+		m.recordMigrationFailure(mig.Name(), wrapped)
+		return wrapped
+	}
+	if err := tx.Commit(); err != nil {
+		wrapped := fmt.Errorf("failed to commit rollback of %s: %w", mig.Name(), err)
+		m.recordMigrationFailure(mig.Name(), wrapped)
+		return wrapped
+	}
+	return nil
+}
+
+// checkForUnknownMigrations returns a *PlanError for the first record whose
+// name has no corresponding entry in m.migrations, unless m.IgnoreUnknown is
+// set.
+func (m *Migrator) checkForUnknownMigrations(records []MigrationRecord) error {
+	if m.IgnoreUnknown {
+		return nil
+	}
+
+	known := make(map[string]bool, len(m.migrations))
+	for _, mig := range m.migrations {
+		known[mig.Name()] = true
+	}
+
+	for _, rec := range records {
+		if !known[rec.Name] {
+			return &PlanError{
+				Migration: rec.Name,
+				Err:       errors.New("has been applied to the database but is not present in the current migration set"),
+			}
+		}
+	}
+	return nil
+}
+
+// appliedInReverseOrder returns every migration in records that still exists
+// in m.migrations, ordered most-recently-applied first.
+func (m *Migrator) appliedInReverseOrder(records []MigrationRecord) []Migration {
+	byName := make(map[string]Migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		byName[mig.Name()] = mig
+	}
+
+	// records is ordered by id, i.e. application order (see GetRanMigrations).
+	applied := []Migration{}
+	for i := len(records) - 1; i >= 0; i-- {
+		if mig, ok := byName[records[i].Name]; ok {
+			applied = append(applied, mig)
+		}
+	}
+	return applied
 }
 
 // Migrate applies all pending migrations.
 func (m *Migrator) Migrate() error {
-	ranMigrations := m.db.GetRanMigrations()
+	return m.MigrateSteps(0)
+}
+
+// MigrateSteps applies at most n pending migrations, in one batch. n <= 0
+// means "apply every pending migration", the same as Migrate.
+func (m *Migrator) MigrateSteps(n int) error {
+	if err := m.ensureMigrationsTable(); err != nil {
+		return err
+	}
+
+	records, err := m.schema.GetRanMigrations()
+	if err != nil {
+		return fmt.Errorf("failed to load migration history: %w", err)
+	}
+	if err := m.checkForUnknownMigrations(records); err != nil {
+		return err
+	}
+
 	ranSet := make(map[string]bool)
-	for _, name := range ranMigrations {
-		ranSet[name] = true
+	lastBatch := 0
+	for _, rec := range records {
+		ranSet[rec.Name] = true
+		if rec.Batch > lastBatch {
+			lastBatch = rec.Batch
+		}
 	}
 
 	pending := []Migration{}
@@ -117,53 +621,62 @@ func (m *Migrator) Migrate() error {
 			pending = append(pending, mig)
 		}
 	}
+	if n > 0 && n < len(pending) {
+		pending = pending[:n]
+	}
 
 	if len(pending) == 0 {
 		fmt.Println("Database is up to date. Nothing to migrate.")
 		return nil
 	}
 
-	batch := m.db.NextBatch
+	batch := lastBatch + 1
 	fmt.Printf("\n--- Running Migrations (Batch %d) ---\n", batch)
 
 	for _, mig := range pending {
 		fmt.Printf("-> Applying %s...\n", mig.Name())
-		if err := mig.Up(m.schema); err != nil {
-			return fmt.Errorf("failed to run migration %s: %w", mig.Name(), err)
+		if err := m.applyMigration(mig, batch); err != nil {
+			return err
 		}
-		m.db.History[mig.Name()] = batch
 	}
 
-	m.db.NextBatch++
 	fmt.Println("--- Migration Complete ---")
 	return nil
 }
 
 // Rollback reverses the migrations from the most recent batch.
 func (m *Migrator) Rollback() error {
-	batchToRollback := m.db.GetLastBatch()
+	if err := m.ensureMigrationsTable(); err != nil {
+		return err
+	}
+
+	records, err := m.schema.GetRanMigrations()
+	if err != nil {
+		return fmt.Errorf("failed to load migration history: %w", err)
+	}
+	if err := m.checkForUnknownMigrations(records); err != nil {
+		return err
+	}
 
+	batchToRollback := 0
+	for _, rec := range records {
+		if rec.Batch > batchToRollback {
+			batchToRollback = rec.Batch
+		}
+	}
 	if batchToRollback == 0 {
 		fmt.Println("\nNo migrations have been run. Nothing to rollback.")
 		return nil
 	}
 
-	migrationsToRevert := []Migration{}
-	migrationsInBatch := make(map[string]bool)
-
-	// 1. Identify migrations belonging to the batch to rollback
-	for name, batch := range m.db.History {
-		if batch == batchToRollback {
-			migrationsInBatch[name] = true
-		}
+	batchOf := make(map[string]int, len(records))
+	for _, rec := range records {
+		batchOf[rec.Name] = rec.Batch
 	}
 
-	// 2. Map names back to the Migration instances and sort them in reverse chronological order
-	// We iterate the full list backwards to get a list of migrations in the last batch,
-	// ordered correctly for reversal.
-	for i := len(m.migrations) - 1; i >= 0; i-- {
-		mig := m.migrations[i]
-		if migrationsInBatch[mig.Name()] {
+	migrationsToRevert := []Migration{}
+	for _, mig := range m.appliedInReverseOrder(records) {
+		if batchOf[mig.Name()] == batchToRollback {
 			migrationsToRevert = append(migrationsToRevert, mig)
 		}
 	}
@@ -177,26 +690,282 @@ func (m *Migrator) Rollback() error {
 
 	for _, mig := range migrationsToRevert {
 		fmt.Printf("<- Reverting %s...\n", mig.Name())
-		if err := mig.Down(m.schema); err != nil {
-			return fmt.Errorf("failed to rollback migration %s: %w", mig.Name(), err)
+		if err := m.revertMigration(mig); err != nil {
+			return err
 		}
-		// Remove from history
-		delete(m.db.History, mig.Name())
 	}
 
-	// Decrement the next batch number so the next run uses the number of the rolled back batch
-	m.db.NextBatch = batchToRollback
+	fmt.Println("--- Rollback Complete ---")
+	return nil
+}
+
+// RollbackSteps reverses the n most-recently applied migrations, regardless
+// of which batch they belong to. n <= 0 means "reverse every applied
+// migration", the same as Reset.
+func (m *Migrator) RollbackSteps(n int) error {
+	if err := m.ensureMigrationsTable(); err != nil {
+		return err
+	}
+
+	records, err := m.schema.GetRanMigrations()
+	if err != nil {
+		return fmt.Errorf("failed to load migration history: %w", err)
+	}
+	if err := m.checkForUnknownMigrations(records); err != nil {
+		return err
+	}
+
+	applied := m.appliedInReverseOrder(records)
+	if len(applied) == 0 {
+		fmt.Println("\nNo migrations have been run. Nothing to rollback.")
+		return nil
+	}
+	if n > 0 && n < len(applied) {
+		applied = applied[:n]
+	}
+
+	fmt.Printf("\n--- Rolling back %d migration(s) ---\n", len(applied))
+
+	for _, mig := range applied {
+		fmt.Printf("<- Reverting %s...\n", mig.Name())
+		if err := m.revertMigration(mig); err != nil {
+			return err
+		}
+	}
 
 	fmt.Println("--- Rollback Complete ---")
 	return nil
 }
 
+// Reset reverses every applied migration, across every batch.
+func (m *Migrator) Reset() error {
+	return m.RollbackSteps(0)
+}
+
+// Refresh reverses every applied migration and then re-runs them all from
+// scratch via their own Down/Up steps. This is Laravel's migrate:refresh
+// semantics: unlike Fresh, it only ever undoes migrations raptor already
+// knows about, so it can't recover a broken or partially applied schema (an
+// orphan table, a migration whose Down doesn't fully undo its Up).
+func (m *Migrator) Refresh() error {
+	if err := m.Reset(); err != nil {
+		return fmt.Errorf("failed to reset before refresh migrate: %w", err)
+	}
+	return m.Migrate()
+}
+
+// Fresh drops every table in the database — not just the ones raptor
+// created — and then re-runs every migration from scratch. Because it
+// drops tables directly instead of replaying Down steps, it can recover a
+// broken or partially applied schema that Refresh can't fix.
+func (m *Migrator) Fresh() error {
+	if err := m.schema.DropAllTables(); err != nil {
+		return fmt.Errorf("failed to drop all tables: %w", err)
+	}
+	if err := m.schema.CreateMigrationsTable(); err != nil {
+		return fmt.Errorf("failed to recreate migrations table: %w", err)
+	}
+	return m.Migrate()
+}
+
+// PendingCount reports how many migrations have not yet been applied,
+// useful for CI checks that should fail when migrations are outstanding.
+func (m *Migrator) PendingCount() (int, error) {
+	if err := m.ensureMigrationsTable(); err != nil {
+		return 0, err
+	}
+
+	records, err := m.schema.GetRanMigrations()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load migration history: %w", err)
+	}
+
+	ranSet := make(map[string]bool, len(records))
+	for _, rec := range records {
+		ranSet[rec.Name] = true
+	}
+
+	count := 0
+	for _, mig := range m.migrations {
+		if !ranSet[mig.Name()] {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// RegisterVersioned adds vm to the set of VersionedMigrations that
+// Start/Complete/RollbackActive can look up by name.
+func (m *Migrator) RegisterVersioned(vm VersionedMigration) {
+	if m.versionedMigrations == nil {
+		m.versionedMigrations = make(map[string]VersionedMigration)
+	}
+	m.versionedMigrations[vm.Name()] = vm
+}
+
+// Start begins the expand phase of the VersionedMigration called name,
+// previously registered via RegisterVersioned. It fails if another
+// versioned migration is already in progress.
+func (m *Migrator) Start(name string) error {
+	if err := m.ensureMigrationsTable(); err != nil {
+		return err
+	}
+
+	vm, ok := m.versionedMigrations[name]
+	if !ok {
+		return fmt.Errorf("no versioned migration registered with name %s", name)
+	}
+
+	active, err := m.schema.ActiveVersionedMigration()
+	if err != nil {
+		return fmt.Errorf("failed to check for an active versioned migration: %w", err)
+	}
+	if active != nil {
+		return fmt.Errorf("cannot start %s: %s is already in progress", name, active.Name)
+	}
+
+	if err := m.schema.BeginVersionedMigration(name); err != nil {
+		return fmt.Errorf("failed to record start of %s: %w", name, err)
+	}
+	if err := vm.Start(m.schema); err != nil {
+		_ = m.schema.RollbackVersionedMigration(name)
+		wrapped := fmt.Errorf("failed to start %s: %w", name, err)
+		m.recordMigrationFailure(name, wrapped)
+		return wrapped
+	}
+
+	fmt.Printf("-> Started versioned migration %s (expand phase)\n", name)
+	return nil
+}
+
+// Complete runs the contract phase of whichever VersionedMigration is
+// currently active, then marks it complete. It is a no-op if none is active.
+func (m *Migrator) Complete() error {
+	active, err := m.schema.ActiveVersionedMigration()
+	if err != nil {
+		return fmt.Errorf("failed to check for an active versioned migration: %w", err)
+	}
+	if active == nil {
+		fmt.Println("No versioned migration is in progress.")
+		return nil
+	}
+
+	vm, ok := m.versionedMigrations[active.Name]
+	if !ok {
+		return fmt.Errorf("no versioned migration registered with name %s", active.Name)
+	}
+
+	if err := vm.Complete(m.schema); err != nil {
+		wrapped := fmt.Errorf("failed to complete %s: %w", active.Name, err)
+		m.recordMigrationFailure(active.Name, wrapped)
+		return wrapped
+	}
+	if err := m.schema.CompleteVersionedMigration(active.Name); err != nil {
+		return fmt.Errorf("failed to record completion of %s: %w", active.Name, err)
+	}
+
+	fmt.Printf("-> Completed versioned migration %s (contract phase)\n", active.Name)
+	return nil
+}
+
+// RollbackActive reverts whichever VersionedMigration is currently active,
+// undoing its expand phase instead of completing it. It is a no-op if none
+// is active.
+func (m *Migrator) RollbackActive() error {
+	active, err := m.schema.ActiveVersionedMigration()
+	if err != nil {
+		return fmt.Errorf("failed to check for an active versioned migration: %w", err)
+	}
+	if active == nil {
+		fmt.Println("No versioned migration is in progress.")
+		return nil
+	}
+
+	vm, ok := m.versionedMigrations[active.Name]
+	if !ok {
+		return fmt.Errorf("no versioned migration registered with name %s", active.Name)
+	}
+
+	if err := vm.Rollback(m.schema); err != nil {
+		wrapped := fmt.Errorf("failed to rollback %s: %w", active.Name, err)
+		m.recordMigrationFailure(active.Name, wrapped)
+		return wrapped
+	}
+	if err := m.schema.RollbackVersionedMigration(active.Name); err != nil {
+		return fmt.Errorf("failed to record rollback of %s: %w", active.Name, err)
+	}
+
+	fmt.Printf("<- Rolled back versioned migration %s\n", active.Name)
+	return nil
+}
+
+// Validate compares m.migrations against the recorded history and returns a
+// ValidationReport of pending, unknown, and out-of-order migrations.
+// Pending migrations alone aren't an error, but it returns a non-nil error
+// alongside the report when it finds unknown or out-of-order migrations,
+// since both mean the database's history no longer matches what's in code.
+func (m *Migrator) Validate() (*ValidationReport, error) {
+	if err := m.ensureMigrationsTable(); err != nil {
+		return nil, err
+	}
+
+	records, err := m.schema.GetRanMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migration history: %w", err)
+	}
+
+	known := make(map[string]bool, len(m.migrations))
+	for _, mig := range m.migrations {
+		known[mig.Name()] = true
+	}
+	ranSet := make(map[string]bool, len(records))
+	for _, rec := range records {
+		ranSet[rec.Name] = true
+	}
+
+	report := &ValidationReport{}
+	for _, mig := range m.migrations {
+		if !ranSet[mig.Name()] {
+			report.Pending = append(report.Pending, mig.Name())
+		}
+	}
+
+	// records is ordered by application (see GetRanMigrations); a name that
+	// sorts before the highest name seen so far was applied out of order.
+	maxName := ""
+	for _, rec := range records {
+		if !known[rec.Name] {
+			report.Unknown = append(report.Unknown, rec.Name)
+		}
+		if rec.Name < maxName {
+			report.OutOfOrder = append(report.OutOfOrder, rec.Name)
+		} else {
+			maxName = rec.Name
+		}
+	}
+
+	if report.HasIssues() {
+		return report, fmt.Errorf("migration history has %d unknown and %d out-of-order migration(s)", len(report.Unknown), len(report.OutOfOrder))
+	}
+	return report, nil
+}
+
 // Status prints the current migration status.
 func (m *Migrator) Status() {
-	ranMigrations := m.db.GetRanMigrations()
-	ranSet := make(map[string]bool)
-	for _, name := range ranMigrations {
-		ranSet[name] = true
+	if err := m.ensureMigrationsTable(); err != nil {
+		fmt.Printf("failed to inspect migration history: %v\n", err)
+		return
+	}
+
+	records, err := m.schema.GetRanMigrations()
+	if err != nil {
+		fmt.Printf("failed to load migration history: %v\n", err)
+		return
+	}
+
+	ranByName := make(map[string]MigrationRecord)
+	for _, rec := range records {
+		ranByName[rec.Name] = rec
 	}
 
 	fmt.Println("\n--- Migration Status ---")
@@ -206,17 +975,17 @@ func (m *Migrator) Status() {
 	pendingCount := 0
 	for _, mig := range m.migrations {
 		name := mig.Name()
-		if batch, ok := m.db.History[name]; ok {
-			fmt.Printf("%-30s %s (%d)\n", name, "Ran", batch)
+		if rec, ok := ranByName[name]; ok {
+			fmt.Printf("%-30s %s (%d)\n", name, "Ran", rec.Batch)
 		} else {
 			fmt.Printf("%-30s %s\n", name, "Pending")
 			pendingCount++
 		}
 	}
 
-	if pendingCount == 0 && len(ranMigrations) > 0 {
+	if pendingCount == 0 && len(records) > 0 {
 		fmt.Println("\nDatabase is fully up to date.")
-	} else if len(ranMigrations) == 0 {
+	} else if len(records) == 0 {
 		fmt.Println("\nNo migrations have been run yet.")
 	}
 	fmt.Println(strings.Repeat("-", 45))
@@ -8,39 +8,133 @@ import (
 	raptor "github.com/mrhoseah/raptor/core"
 )
 
+// migrationsTableMySQL is the name of the history table tracked in every
+// MySQL database managed by raptor.
+const migrationsTableMySQL = "raptor_migrations"
+
+// mysqlColumnSQL translates a neutral Column into MySQL DDL, e.g. using
+// AUTO_INCREMENT for auto-incrementing integers.
+func mysqlColumnSQL(col *raptor.Column) string {
+	var typeSQL string
+	switch col.Type {
+	case raptor.String:
+		length := col.Length
+		if length == 0 {
+			length = 255
+		}
+		typeSQL = fmt.Sprintf("VARCHAR(%d)", length)
+	case raptor.Text:
+		typeSQL = "TEXT"
+	case raptor.Integer:
+		typeSQL = "INT"
+	case raptor.BigInteger:
+		typeSQL = "BIGINT"
+	case raptor.Boolean:
+		typeSQL = "TINYINT(1)"
+	case raptor.Timestamp:
+		typeSQL = "TIMESTAMP"
+	case raptor.Date:
+		typeSQL = "DATE"
+	case raptor.Float:
+		typeSQL = "DOUBLE"
+	}
+
+	def := fmt.Sprintf("`%s` %s", col.Name, typeSQL)
+	if col.IsAutoIncrement {
+		def += " AUTO_INCREMENT"
+	}
+	if col.IsPrimary {
+		def += " PRIMARY KEY"
+	}
+	if col.IsNotNull {
+		def += " NOT NULL"
+	}
+	if col.IsUnique {
+		def += " UNIQUE"
+	}
+	if col.HasDefault {
+		def += " DEFAULT " + col.DefaultValue
+	}
+	return def
+}
+
+// mysqlForeignKeySQL translates a ForeignKey into a FOREIGN KEY table constraint.
+func mysqlForeignKeySQL(fk *raptor.ForeignKey) string {
+	def := fmt.Sprintf("FOREIGN KEY (`%s`) REFERENCES `%s`(`%s`)", fk.Column, fk.RefTable, fk.RefColumn)
+	if fk.OnDeleteAction != "" {
+		def += " ON DELETE " + fk.OnDeleteAction
+	}
+	if fk.OnUpdateAction != "" {
+		def += " ON UPDATE " + fk.OnUpdateAction
+	}
+	return def
+}
+
+// mysqlIndexSQL translates an IndexDef into a CREATE INDEX statement.
+func mysqlIndexSQL(table string, idx *raptor.IndexDef) string {
+	kind := "INDEX"
+	if idx.IsUnique {
+		kind = "UNIQUE INDEX"
+	}
+	return fmt.Sprintf("CREATE %s `%s` ON `%s` (%s);", kind, idx.Name, table, strings.Join(idx.Columns, ", "))
+}
+
 // MySQLSchema implements the raptor.Schema interface for MySQL/MariaDB.
+//
+// MySQLSchema deliberately does not implement raptor.TxSchema: MySQL's DDL
+// statements trigger an implicit commit, so wrapping CreateTable/DropTable in
+// a transaction wouldn't actually make them atomic with the history-row
+// write. Migrator detects this and falls back to its non-transactional path.
 type MySQLSchema struct {
 	DB *sql.DB
 }
 
+// NewMySQLSchema creates a MySQLSchema backed by db.
+func NewMySQLSchema(db *sql.DB) *MySQLSchema {
+	return &MySQLSchema{DB: db}
+}
+
 // Ensure MySQLSchema satisfies the raptor.Schema interface at compile time.
 var _ raptor.Schema = (*MySQLSchema)(nil)
 
 // CreateTable builds and executes MySQL-specific SQL to create a table.
-func (s *MySQLSchema) CreateTable(name string, columns []string) error {
-	// Note: MySQL often uses backticks (`) for identifiers.
-	// We'll simulate a basic schema definition.
-	columnDefs := []string{}
-	for i, col := range columns {
-		def := fmt.Sprintf("`%s` VARCHAR(255) NOT NULL", col)
-		if i == 0 {
-			def = fmt.Sprintf("`%s` INT AUTO_INCREMENT PRIMARY KEY", col) // Assume first column is primary key
-		}
-		columnDefs = append(columnDefs, def)
+func (s *MySQLSchema) CreateTable(name string, build func(*raptor.Blueprint)) error {
+	bp := raptor.NewBlueprint(name)
+	build(bp)
+
+	defs := make([]string, 0, len(bp.Columns)+len(bp.ForeignKeys))
+	for _, col := range bp.Columns {
+		defs = append(defs, mysqlColumnSQL(col))
+	}
+	for _, fk := range bp.ForeignKeys {
+		defs = append(defs, mysqlForeignKeySQL(fk))
 	}
 
 	sqlStmt := fmt.Sprintf("CREATE TABLE IF NOT EXISTS `%s` (%s) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;",
 		name,
-		strings.Join(columnDefs, ", "),
+		strings.Join(defs, ", "),
 	)
 
 	fmt.Printf("[MySQL] Executing: %s\n", sqlStmt)
 
-	// In a real application, you would execute:
-	// _, err := s.DB.Exec(sqlStmt)
-	// return err
+	if _, err := s.DB.Exec(sqlStmt); err != nil {
+		return err
+	}
+
+	for _, idx := range bp.Indexes {
+		idxSQL := mysqlIndexSQL(name, idx)
+		fmt.Printf("[MySQL] Executing: %s\n", idxSQL)
+		if _, err := s.DB.Exec(idxSQL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	return nil // Simulated success
+// Exec runs a raw SQL statement directly against the connection, used by
+// file-based migrations loaded via raptor.LoadMigrationsFromDir.
+func (s *MySQLSchema) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return s.DB.Exec(query, args...)
 }
 
 // DropTable executes MySQL-specific SQL to drop a table.
@@ -49,9 +143,206 @@ func (s *MySQLSchema) DropTable(name string) error {
 
 	fmt.Printf("[MySQL] Executing: %s\n", sqlStmt)
 
-	// In a real application, you would execute:
-	// _, err := s.DB.Exec(sqlStmt)
-	// return err
+	_, err := s.DB.Exec(sqlStmt)
+	return err
+}
+
+// DropAllTables enumerates every table in the current database and drops
+// them all, including ones raptor didn't create, so Migrator.Fresh can
+// recover from a broken or partially applied schema. Foreign key checks are
+// disabled for the duration so drop order doesn't matter.
+func (s *MySQLSchema) DropAllTables() error {
+	rows, err := s.DB.Query("SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE();")
+	if err != nil {
+		return err
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		names = append(names, fmt.Sprintf("`%s`", name))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	if _, err := s.DB.Exec("SET FOREIGN_KEY_CHECKS = 0;"); err != nil {
+		return err
+	}
+
+	sqlStmt := fmt.Sprintf("DROP TABLE IF EXISTS %s;", strings.Join(names, ", "))
+	fmt.Printf("[MySQL] Executing: %s\n", sqlStmt)
+	if _, err := s.DB.Exec(sqlStmt); err != nil {
+		return err
+	}
+
+	_, err = s.DB.Exec("SET FOREIGN_KEY_CHECKS = 1;")
+	return err
+}
+
+// AddColumn adds the column(s) described by build to an existing table.
+func (s *MySQLSchema) AddColumn(table string, build func(*raptor.Blueprint)) error {
+	bp := raptor.NewBlueprint(table)
+	build(bp)
+
+	for _, col := range bp.Columns {
+		sqlStmt := fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN %s;", table, mysqlColumnSQL(col))
+		fmt.Printf("[MySQL] Executing: %s\n", sqlStmt)
+		if _, err := s.DB.Exec(sqlStmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DropColumn removes a single column from an existing table.
+func (s *MySQLSchema) DropColumn(table, column string) error {
+	sqlStmt := fmt.Sprintf("ALTER TABLE `%s` DROP COLUMN `%s`;", table, column)
+	fmt.Printf("[MySQL] Executing: %s\n", sqlStmt)
+	_, err := s.DB.Exec(sqlStmt)
+	return err
+}
+
+// RenameColumn renames a single column on an existing table. MySQL 8+ supports
+// RENAME COLUMN directly; older MariaDB/MySQL would need CHANGE COLUMN with a
+// repeated type, which is out of scope here.
+func (s *MySQLSchema) RenameColumn(table, oldName, newName string) error {
+	sqlStmt := fmt.Sprintf("ALTER TABLE `%s` RENAME COLUMN `%s` TO `%s`;", table, oldName, newName)
+	fmt.Printf("[MySQL] Executing: %s\n", sqlStmt)
+	_, err := s.DB.Exec(sqlStmt)
+	return err
+}
+
+// RenameTable renames an existing table.
+func (s *MySQLSchema) RenameTable(oldName, newName string) error {
+	sqlStmt := fmt.Sprintf("RENAME TABLE `%s` TO `%s`;", oldName, newName)
+	fmt.Printf("[MySQL] Executing: %s\n", sqlStmt)
+	_, err := s.DB.Exec(sqlStmt)
+	return err
+}
+
+// AddIndex adds the index(es) described by build to an existing table.
+func (s *MySQLSchema) AddIndex(table string, build func(*raptor.Blueprint)) error {
+	bp := raptor.NewBlueprint(table)
+	build(bp)
+
+	for _, idx := range bp.Indexes {
+		sqlStmt := mysqlIndexSQL(table, idx)
+		fmt.Printf("[MySQL] Executing: %s\n", sqlStmt)
+		if _, err := s.DB.Exec(sqlStmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DropIndex removes a named index from an existing table.
+func (s *MySQLSchema) DropIndex(table, indexName string) error {
+	sqlStmt := fmt.Sprintf("DROP INDEX `%s` ON `%s`;", indexName, table)
+	fmt.Printf("[MySQL] Executing: %s\n", sqlStmt)
+	_, err := s.DB.Exec(sqlStmt)
+	return err
+}
+
+// HasMigrationsTable reports whether raptor_migrations already exists in the
+// current database.
+func (s *MySQLSchema) HasMigrationsTable() (bool, error) {
+	row := s.DB.QueryRow(
+		"SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?",
+		migrationsTableMySQL,
+	)
+	var name string
+	if err := row.Scan(&name); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateMigrationsTable creates the raptor_migrations history table.
+func (s *MySQLSchema) CreateMigrationsTable() error {
+	sqlStmt := fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS `%s` (`id` INT AUTO_INCREMENT PRIMARY KEY, `name` VARCHAR(255) NOT NULL UNIQUE, `batch` INT NOT NULL, `applied_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;",
+		migrationsTableMySQL,
+	)
+
+	fmt.Printf("[MySQL] Executing: %s\n", sqlStmt)
+
+	_, err := s.DB.Exec(sqlStmt)
+	return err
+}
+
+// InsertMigration records a migration as applied in the given batch.
+func (s *MySQLSchema) InsertMigration(name string, batch int) error {
+	sqlStmt := fmt.Sprintf("INSERT INTO `%s` (`name`, `batch`) VALUES (?, ?);", migrationsTableMySQL)
+	_, err := s.DB.Exec(sqlStmt, name, batch)
+	return err
+}
+
+// DeleteMigration removes a migration's history row, used on rollback.
+func (s *MySQLSchema) DeleteMigration(name string) error {
+	sqlStmt := fmt.Sprintf("DELETE FROM `%s` WHERE `name` = ?;", migrationsTableMySQL)
+	_, err := s.DB.Exec(sqlStmt, name)
+	return err
+}
+
+// CreateVersionedView is not supported by MySQL: raptor's zero-downtime
+// expand/contract migrations currently depend on Postgres views and triggers.
+func (s *MySQLSchema) CreateVersionedView(schema, version string, mapping map[string]string) error {
+	return raptor.ErrUnsupported
+}
+
+// DropVersionedView is not supported by MySQL; see CreateVersionedView.
+func (s *MySQLSchema) DropVersionedView(schema, version string) error {
+	return raptor.ErrUnsupported
+}
+
+// BeginVersionedMigration is not supported by MySQL; see CreateVersionedView.
+func (s *MySQLSchema) BeginVersionedMigration(name string) error {
+	return raptor.ErrUnsupported
+}
+
+// CompleteVersionedMigration is not supported by MySQL; see CreateVersionedView.
+func (s *MySQLSchema) CompleteVersionedMigration(name string) error {
+	return raptor.ErrUnsupported
+}
+
+// RollbackVersionedMigration is not supported by MySQL; see CreateVersionedView.
+func (s *MySQLSchema) RollbackVersionedMigration(name string) error {
+	return raptor.ErrUnsupported
+}
+
+// ActiveVersionedMigration is not supported by MySQL; see CreateVersionedView.
+func (s *MySQLSchema) ActiveVersionedMigration() (*raptor.VersionedMigrationState, error) {
+	return nil, raptor.ErrUnsupported
+}
+
+// GetRanMigrations returns every recorded migration, ordered by id.
+func (s *MySQLSchema) GetRanMigrations() ([]raptor.MigrationRecord, error) {
+	sqlStmt := fmt.Sprintf("SELECT `id`, `name`, `batch`, `applied_at` FROM `%s` ORDER BY `id`;", migrationsTableMySQL)
+	rows, err := s.DB.Query(sqlStmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	return nil // Simulated success
+	records := []raptor.MigrationRecord{}
+	for rows.Next() {
+		var rec raptor.MigrationRecord
+		if err := rows.Scan(&rec.ID, &rec.Name, &rec.Batch, &rec.AppliedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
 }
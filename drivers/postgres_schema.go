@@ -3,53 +3,434 @@ package drivers
 import (
 	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 
 	raptor "github.com/mrhoseah/raptor/core"
 )
 
-// PostgresSchema implements the raptor.Schema interface for PostgreSQL.
-type PostgresSchema struct {
-	DB *sql.DB
-}
+// migrationsTablePostgres is the name of the history table tracked in every
+// PostgreSQL database managed by raptor.
+const migrationsTablePostgres = "raptor_migrations"
 
-// Ensure PostgresSchema satisfies the raptor.Schema interface at compile time.
-var _ raptor.Schema = (*PostgresSchema)(nil)
+// pgExecutor is satisfied by both *sql.DB and *sql.Tx, so postgresOps can
+// implement the Schema DDL once and be shared by PostgresSchema (running
+// against the connection) and PostgresTxSchema (running against a transaction).
+type pgExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
 
-// CreateTable builds and executes PostgreSQL-specific SQL to create a table.
-func (s *PostgresSchema) CreateTable(name string, columns []string) error {
-	// PostgreSQL prefers standard double quotes (") for identifiers,
-	// and uses specific syntax like SERIAL for auto-incrementing integers.
-	columnDefs := []string{}
-	for i, col := range columns {
-		def := fmt.Sprintf("%s VARCHAR(255) NOT NULL", col)
-		if i == 0 {
-			def = fmt.Sprintf("%s SERIAL PRIMARY KEY", col) // Assume first column is primary key
+// postgresColumnSQL translates a neutral Column into PostgreSQL DDL,
+// e.g. using SERIAL for auto-incrementing integers.
+func postgresColumnSQL(col *raptor.Column) string {
+	var typeSQL string
+	switch col.Type {
+	case raptor.String:
+		length := col.Length
+		if length == 0 {
+			length = 255
+		}
+		typeSQL = fmt.Sprintf("VARCHAR(%d)", length)
+	case raptor.Text:
+		typeSQL = "TEXT"
+	case raptor.Integer:
+		if col.IsAutoIncrement {
+			typeSQL = "SERIAL"
+		} else {
+			typeSQL = "INTEGER"
 		}
-		columnDefs = append(columnDefs, def)
+	case raptor.BigInteger:
+		if col.IsAutoIncrement {
+			typeSQL = "BIGSERIAL"
+		} else {
+			typeSQL = "BIGINT"
+		}
+	case raptor.Boolean:
+		typeSQL = "BOOLEAN"
+	case raptor.Timestamp:
+		typeSQL = "TIMESTAMP"
+	case raptor.Date:
+		typeSQL = "DATE"
+	case raptor.Float:
+		typeSQL = "DOUBLE PRECISION"
 	}
 
-	sqlStmt := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s);", name, strings.Join(columnDefs, ", "))
+	def := fmt.Sprintf("%s %s", col.Name, typeSQL)
+	if col.IsPrimary {
+		def += " PRIMARY KEY"
+	}
+	if col.IsNotNull {
+		def += " NOT NULL"
+	}
+	if col.IsUnique {
+		def += " UNIQUE"
+	}
+	if col.HasDefault {
+		def += " DEFAULT " + col.DefaultValue
+	}
+	return def
+}
 
+// postgresForeignKeySQL translates a ForeignKey into a FOREIGN KEY table constraint.
+func postgresForeignKeySQL(fk *raptor.ForeignKey) string {
+	def := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s(%s)", fk.Column, fk.RefTable, fk.RefColumn)
+	if fk.OnDeleteAction != "" {
+		def += " ON DELETE " + fk.OnDeleteAction
+	}
+	if fk.OnUpdateAction != "" {
+		def += " ON UPDATE " + fk.OnUpdateAction
+	}
+	return def
+}
+
+// postgresIndexSQL translates an IndexDef into a CREATE INDEX statement.
+func postgresIndexSQL(table string, idx *raptor.IndexDef) string {
+	kind := "INDEX"
+	if idx.IsUnique {
+		kind = "UNIQUE INDEX"
+	}
+	return fmt.Sprintf("CREATE %s IF NOT EXISTS %s ON %s (%s);", kind, idx.Name, table, strings.Join(idx.Columns, ", "))
+}
+
+// postgresOps implements the Schema DDL shared by PostgresSchema and
+// PostgresTxSchema against whichever executor (DB or Tx) it is given.
+type postgresOps struct {
+	exec pgExecutor
+}
+
+// CreateTable builds and executes PostgreSQL-specific SQL to create a table.
+func (o *postgresOps) CreateTable(name string, build func(*raptor.Blueprint)) error {
+	bp := raptor.NewBlueprint(name)
+	build(bp)
+
+	defs := make([]string, 0, len(bp.Columns)+len(bp.ForeignKeys))
+	for _, col := range bp.Columns {
+		defs = append(defs, postgresColumnSQL(col))
+	}
+	for _, fk := range bp.ForeignKeys {
+		defs = append(defs, postgresForeignKeySQL(fk))
+	}
+
+	sqlStmt := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s);", name, strings.Join(defs, ", "))
 	fmt.Printf("[PostgreSQL] Executing: %s\n", sqlStmt)
+	if _, err := o.exec.Exec(sqlStmt); err != nil {
+		return err
+	}
 
-	// In a real application, you would execute:
-	// _, err := s.DB.Exec(sqlStmt)
-	// return err
+	for _, idx := range bp.Indexes {
+		idxSQL := postgresIndexSQL(name, idx)
+		fmt.Printf("[PostgreSQL] Executing: %s\n", idxSQL)
+		if _, err := o.exec.Exec(idxSQL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	return nil // Simulated success
+// Exec runs a raw SQL statement directly against the executor, used by
+// file-based migrations loaded via raptor.LoadMigrationsFromDir.
+func (o *postgresOps) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return o.exec.Exec(query, args...)
 }
 
 // DropTable executes PostgreSQL-specific SQL to drop a table.
-func (s *PostgresSchema) DropTable(name string) error {
+func (o *postgresOps) DropTable(name string) error {
 	// POSTGRES uses "CASCADE" or "RESTRICT" options, but we'll use IF EXISTS for safety.
 	sqlStmt := fmt.Sprintf("DROP TABLE IF EXISTS %s;", name)
 
 	fmt.Printf("[PostgreSQL] Executing: %s\n", sqlStmt)
 
-	// In a real application, you would execute:
-	// _, err := s.DB.Exec(sqlStmt)
-	// return err
+	_, err := o.exec.Exec(sqlStmt)
+	return err
+}
+
+// DropAllTables enumerates every base table in the public schema and drops
+// them all, including ones raptor didn't create, so Migrator.Fresh can
+// recover from a broken or partially applied schema.
+func (o *postgresOps) DropAllTables() error {
+	rows, err := o.exec.Query("SELECT table_name FROM information_schema.tables WHERE table_schema = 'public' AND table_type = 'BASE TABLE';")
+	if err != nil {
+		return err
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	sqlStmt := fmt.Sprintf("DROP TABLE IF EXISTS %s CASCADE;", strings.Join(names, ", "))
+	fmt.Printf("[PostgreSQL] Executing: %s\n", sqlStmt)
+	_, err = o.exec.Exec(sqlStmt)
+	return err
+}
+
+// AddColumn adds the column(s) described by build to an existing table.
+func (o *postgresOps) AddColumn(table string, build func(*raptor.Blueprint)) error {
+	bp := raptor.NewBlueprint(table)
+	build(bp)
 
-	return nil // Simulated success
+	for _, col := range bp.Columns {
+		sqlStmt := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", table, postgresColumnSQL(col))
+		fmt.Printf("[PostgreSQL] Executing: %s\n", sqlStmt)
+		if _, err := o.exec.Exec(sqlStmt); err != nil {
+			return err
+		}
+	}
+	return nil
 }
+
+// DropColumn removes a single column from an existing table.
+func (o *postgresOps) DropColumn(table, column string) error {
+	sqlStmt := fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", table, column)
+	fmt.Printf("[PostgreSQL] Executing: %s\n", sqlStmt)
+	_, err := o.exec.Exec(sqlStmt)
+	return err
+}
+
+// RenameColumn renames a single column on an existing table.
+func (o *postgresOps) RenameColumn(table, oldName, newName string) error {
+	sqlStmt := fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", table, oldName, newName)
+	fmt.Printf("[PostgreSQL] Executing: %s\n", sqlStmt)
+	_, err := o.exec.Exec(sqlStmt)
+	return err
+}
+
+// RenameTable renames an existing table.
+func (o *postgresOps) RenameTable(oldName, newName string) error {
+	sqlStmt := fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", oldName, newName)
+	fmt.Printf("[PostgreSQL] Executing: %s\n", sqlStmt)
+	_, err := o.exec.Exec(sqlStmt)
+	return err
+}
+
+// AddIndex adds the index(es) described by build to an existing table.
+func (o *postgresOps) AddIndex(table string, build func(*raptor.Blueprint)) error {
+	bp := raptor.NewBlueprint(table)
+	build(bp)
+
+	for _, idx := range bp.Indexes {
+		sqlStmt := postgresIndexSQL(table, idx)
+		fmt.Printf("[PostgreSQL] Executing: %s\n", sqlStmt)
+		if _, err := o.exec.Exec(sqlStmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DropIndex removes a named index from an existing table.
+func (o *postgresOps) DropIndex(table, indexName string) error {
+	sqlStmt := fmt.Sprintf("DROP INDEX IF EXISTS %s;", indexName)
+	fmt.Printf("[PostgreSQL] Executing: %s\n", sqlStmt)
+	_, err := o.exec.Exec(sqlStmt)
+	return err
+}
+
+// HasMigrationsTable reports whether raptor_migrations already exists in the
+// public schema.
+func (o *postgresOps) HasMigrationsTable() (bool, error) {
+	row := o.exec.QueryRow(
+		"SELECT table_name FROM information_schema.tables WHERE table_schema = 'public' AND table_name = $1",
+		migrationsTablePostgres,
+	)
+	var name string
+	if err := row.Scan(&name); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateMigrationsTable creates the raptor_migrations history table. The
+// state column (default 'complete') and the partial unique index beneath it
+// support versioned migrations: CompleteVersionedMigration/
+// RollbackVersionedMigration move a row out of 'in_progress', and the index
+// relies on Postgres rejecting a second 'in_progress' row to enforce that at
+// most one versioned migration is active at a time.
+func (o *postgresOps) CreateMigrationsTable() error {
+	sqlStmt := fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (id SERIAL PRIMARY KEY, name TEXT NOT NULL UNIQUE, batch INTEGER NOT NULL, state TEXT NOT NULL DEFAULT 'complete', applied_at TIMESTAMP NOT NULL DEFAULT NOW());",
+		migrationsTablePostgres,
+	)
+
+	fmt.Printf("[PostgreSQL] Executing: %s\n", sqlStmt)
+
+	if _, err := o.exec.Exec(sqlStmt); err != nil {
+		return err
+	}
+
+	indexSQL := fmt.Sprintf(
+		"CREATE UNIQUE INDEX IF NOT EXISTS %s_one_active_idx ON %s (state) WHERE state = 'in_progress';",
+		migrationsTablePostgres, migrationsTablePostgres,
+	)
+	fmt.Printf("[PostgreSQL] Executing: %s\n", indexSQL)
+
+	_, err := o.exec.Exec(indexSQL)
+	return err
+}
+
+// InsertMigration records a migration as applied in the given batch.
+func (o *postgresOps) InsertMigration(name string, batch int) error {
+	sqlStmt := fmt.Sprintf("INSERT INTO %s (name, batch) VALUES ($1, $2);", migrationsTablePostgres)
+	_, err := o.exec.Exec(sqlStmt, name, batch)
+	return err
+}
+
+// DeleteMigration removes a migration's history row, used on rollback.
+func (o *postgresOps) DeleteMigration(name string) error {
+	sqlStmt := fmt.Sprintf("DELETE FROM %s WHERE name = $1;", migrationsTablePostgres)
+	_, err := o.exec.Exec(sqlStmt, name)
+	return err
+}
+
+// GetRanMigrations returns every recorded regular migration, ordered by id.
+// BeginVersionedMigration always records its bookkeeping row with batch = 0,
+// which no regular migration ever uses (MigrateSteps starts batches at 1),
+// so filtering on batch > 0 excludes every versioned migration's row
+// regardless of its state ('in_progress', 'rolled_back', or 'complete') —
+// otherwise a *completed* versioned migration would masquerade forever as
+// an applied regular migration with no corresponding entry in code.
+func (o *postgresOps) GetRanMigrations() ([]raptor.MigrationRecord, error) {
+	sqlStmt := fmt.Sprintf("SELECT id, name, batch, applied_at FROM %s WHERE state = 'complete' AND batch > 0 ORDER BY id;", migrationsTablePostgres)
+	rows, err := o.exec.Query(sqlStmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []raptor.MigrationRecord{}
+	for rows.Next() {
+		var rec raptor.MigrationRecord
+		if err := rows.Scan(&rec.ID, &rec.Name, &rec.Batch, &rec.AppliedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+// CreateVersionedView creates a view named "<schema>_<version>" projecting
+// mapping (view column name -> underlying expression) over schema, so
+// readers can keep querying the old shape while an expand migration
+// backfills the new one.
+func (o *postgresOps) CreateVersionedView(schema, version string, mapping map[string]string) error {
+	viewName := fmt.Sprintf("%s_%s", schema, version)
+
+	cols := make([]string, 0, len(mapping))
+	for alias, expr := range mapping {
+		cols = append(cols, fmt.Sprintf("%s AS %s", expr, alias))
+	}
+	sort.Strings(cols) // map iteration order is random; keep the generated SQL deterministic
+
+	sqlStmt := fmt.Sprintf("CREATE OR REPLACE VIEW %s AS SELECT %s FROM %s;", viewName, strings.Join(cols, ", "), schema)
+	fmt.Printf("[PostgreSQL] Executing: %s\n", sqlStmt)
+	_, err := o.exec.Exec(sqlStmt)
+	return err
+}
+
+// DropVersionedView drops the view created by CreateVersionedView for the
+// given schema/version pair.
+func (o *postgresOps) DropVersionedView(schema, version string) error {
+	viewName := fmt.Sprintf("%s_%s", schema, version)
+	sqlStmt := fmt.Sprintf("DROP VIEW IF EXISTS %s;", viewName)
+	fmt.Printf("[PostgreSQL] Executing: %s\n", sqlStmt)
+	_, err := o.exec.Exec(sqlStmt)
+	return err
+}
+
+// BeginVersionedMigration records name as the in-progress versioned
+// migration. The partial unique index on raptor_migrations(state) rejects
+// the insert if another migration is already in progress.
+func (o *postgresOps) BeginVersionedMigration(name string) error {
+	sqlStmt := fmt.Sprintf("INSERT INTO %s (name, batch, state) VALUES ($1, 0, 'in_progress');", migrationsTablePostgres)
+	_, err := o.exec.Exec(sqlStmt, name)
+	return err
+}
+
+// CompleteVersionedMigration marks the active versioned migration complete.
+func (o *postgresOps) CompleteVersionedMigration(name string) error {
+	sqlStmt := fmt.Sprintf("UPDATE %s SET state = 'complete' WHERE name = $1;", migrationsTablePostgres)
+	_, err := o.exec.Exec(sqlStmt, name)
+	return err
+}
+
+// RollbackVersionedMigration marks the active versioned migration rolled back.
+func (o *postgresOps) RollbackVersionedMigration(name string) error {
+	sqlStmt := fmt.Sprintf("UPDATE %s SET state = 'rolled_back' WHERE name = $1;", migrationsTablePostgres)
+	_, err := o.exec.Exec(sqlStmt, name)
+	return err
+}
+
+// ActiveVersionedMigration returns the versioned migration currently in
+// progress, or nil if none is active.
+func (o *postgresOps) ActiveVersionedMigration() (*raptor.VersionedMigrationState, error) {
+	row := o.exec.QueryRow(fmt.Sprintf("SELECT name, state, applied_at FROM %s WHERE state = 'in_progress' LIMIT 1;", migrationsTablePostgres))
+
+	var st raptor.VersionedMigrationState
+	if err := row.Scan(&st.Name, &st.State, &st.AppliedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &st, nil
+}
+
+// PostgresSchema implements the raptor.Schema interface for PostgreSQL.
+type PostgresSchema struct {
+	DB *sql.DB
+	postgresOps
+}
+
+// NewPostgresSchema creates a PostgresSchema backed by db.
+func NewPostgresSchema(db *sql.DB) *PostgresSchema {
+	return &PostgresSchema{DB: db, postgresOps: postgresOps{exec: db}}
+}
+
+// Ensure PostgresSchema satisfies the raptor.Schema and raptor.TxSchema
+// interfaces at compile time. Postgres DDL is transactional, so it can
+// safely participate in Migrator's UseTransaction mode.
+var _ raptor.Schema = (*PostgresSchema)(nil)
+var _ raptor.TxSchema = (*PostgresSchema)(nil)
+
+// BeginTx starts a transaction on the underlying connection.
+func (s *PostgresSchema) BeginTx() (*sql.Tx, error) {
+	return s.DB.Begin()
+}
+
+// WithTx returns a Schema whose operations run against tx instead of DB.
+func (s *PostgresSchema) WithTx(tx *sql.Tx) raptor.Schema {
+	return NewPostgresTxSchema(tx)
+}
+
+// PostgresTxSchema is the transaction-scoped counterpart of PostgresSchema,
+// returned by PostgresSchema.WithTx so a migration's Up/Down call and its
+// history-row write share one *sql.Tx.
+type PostgresTxSchema struct {
+	Tx *sql.Tx
+	postgresOps
+}
+
+// NewPostgresTxSchema creates a PostgresTxSchema backed by tx.
+func NewPostgresTxSchema(tx *sql.Tx) *PostgresTxSchema {
+	return &PostgresTxSchema{Tx: tx, postgresOps: postgresOps{exec: tx}}
+}
+
+// Ensure PostgresTxSchema satisfies the raptor.Schema interface at compile time.
+var _ raptor.Schema = (*PostgresTxSchema)(nil)
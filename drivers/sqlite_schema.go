@@ -8,42 +8,371 @@ import (
 	raptor "github.com/mrhoseah/raptor/core"
 )
 
-// SQLiteSchema implements the raptor.Schema interface for SQLite databases.
-// It requires a standard sql.DB connection.
-type SQLiteSchema struct {
-	DB *sql.DB
+// migrationsTableSQLite is the name of the history table tracked in every
+// SQLite database managed by raptor.
+const migrationsTableSQLite = "raptor_migrations"
+
+// sqliteExecutor is satisfied by both *sql.DB and *sql.Tx, so sqliteOps can
+// implement the Schema DDL once and be shared by SQLiteSchema (running
+// against the connection) and SQLiteTxSchema (running against a transaction).
+type sqliteExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
 }
 
-// Ensure SQLiteSchema satisfies the raptor.Schema interface at compile time.
-var _ raptor.Schema = (*SQLiteSchema)(nil)
+// sqliteColumnSQL translates a neutral Column into SQLite DDL. SQLite only
+// recognizes AUTOINCREMENT on a lone "INTEGER PRIMARY KEY" column, so that
+// combination is special-cased.
+func sqliteColumnSQL(col *raptor.Column) string {
+	if col.Type == raptor.Integer && col.IsPrimary && col.IsAutoIncrement {
+		def := fmt.Sprintf("%s INTEGER PRIMARY KEY AUTOINCREMENT", col.Name)
+		if col.IsNotNull {
+			def += " NOT NULL"
+		}
+		if col.IsUnique {
+			def += " UNIQUE"
+		}
+		if col.HasDefault {
+			def += " DEFAULT " + col.DefaultValue
+		}
+		return def
+	}
+
+	var typeSQL string
+	switch col.Type {
+	case raptor.String, raptor.Text:
+		typeSQL = "TEXT"
+	case raptor.Integer, raptor.BigInteger:
+		typeSQL = "INTEGER"
+	case raptor.Boolean:
+		typeSQL = "BOOLEAN"
+	case raptor.Timestamp:
+		typeSQL = "DATETIME"
+	case raptor.Date:
+		typeSQL = "DATE"
+	case raptor.Float:
+		typeSQL = "REAL"
+	}
+
+	def := fmt.Sprintf("%s %s", col.Name, typeSQL)
+	if col.IsPrimary {
+		def += " PRIMARY KEY"
+	}
+	if col.IsNotNull {
+		def += " NOT NULL"
+	}
+	if col.IsUnique {
+		def += " UNIQUE"
+	}
+	if col.HasDefault {
+		def += " DEFAULT " + col.DefaultValue
+	}
+	return def
+}
+
+// sqliteForeignKeySQL translates a ForeignKey into a FOREIGN KEY table constraint.
+func sqliteForeignKeySQL(fk *raptor.ForeignKey) string {
+	def := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s(%s)", fk.Column, fk.RefTable, fk.RefColumn)
+	if fk.OnDeleteAction != "" {
+		def += " ON DELETE " + fk.OnDeleteAction
+	}
+	if fk.OnUpdateAction != "" {
+		def += " ON UPDATE " + fk.OnUpdateAction
+	}
+	return def
+}
+
+// sqliteIndexSQL translates an IndexDef into a CREATE INDEX statement.
+func sqliteIndexSQL(table string, idx *raptor.IndexDef) string {
+	kind := "INDEX"
+	if idx.IsUnique {
+		kind = "UNIQUE INDEX"
+	}
+	return fmt.Sprintf("CREATE %s IF NOT EXISTS %s ON %s (%s);", kind, idx.Name, table, strings.Join(idx.Columns, ", "))
+}
+
+// sqliteOps implements the Schema DDL shared by SQLiteSchema and
+// SQLiteTxSchema against whichever executor (DB or Tx) it is given.
+type sqliteOps struct {
+	exec sqliteExecutor
+}
 
 // CreateTable builds and executes SQLite-specific SQL to create a table.
-func (s *SQLiteSchema) CreateTable(name string, columns []string) error {
-	// Simple column definition simulation. In a real builder, this would
-	// translate column types (e.g., 'id' -> 'INTEGER PRIMARY KEY').
-	columnsSQL := strings.Join(columns, " TEXT, ") + " TEXT"
+func (o *sqliteOps) CreateTable(name string, build func(*raptor.Blueprint)) error {
+	bp := raptor.NewBlueprint(name)
+	build(bp)
 
-	// SQLite uses standard SQL syntax for CREATE TABLE
-	sqlStmt := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s);", name, columnsSQL)
+	defs := make([]string, 0, len(bp.Columns)+len(bp.ForeignKeys))
+	for _, col := range bp.Columns {
+		defs = append(defs, sqliteColumnSQL(col))
+	}
+	for _, fk := range bp.ForeignKeys {
+		defs = append(defs, sqliteForeignKeySQL(fk))
+	}
 
+	sqlStmt := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s);", name, strings.Join(defs, ", "))
 	fmt.Printf("[SQLite] Executing: %s\n", sqlStmt)
+	if _, err := o.exec.Exec(sqlStmt); err != nil {
+		return err
+	}
+
+	for _, idx := range bp.Indexes {
+		idxSQL := sqliteIndexSQL(name, idx)
+		fmt.Printf("[SQLite] Executing: %s\n", idxSQL)
+		if _, err := o.exec.Exec(idxSQL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Exec runs a raw SQL statement directly against the executor, used by
+// file-based migrations loaded via raptor.LoadMigrationsFromDir.
+func (o *sqliteOps) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return o.exec.Exec(query, args...)
+}
 
-	// In a real application, you would execute:
-	// _, err := s.DB.Exec(sqlStmt)
-	// return err
+// DropAllTables enumerates every table in sqlite_master and drops them all,
+// including ones raptor didn't create, so Migrator.Fresh can recover from a
+// broken or partially applied schema.
+func (o *sqliteOps) DropAllTables() error {
+	rows, err := o.exec.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%';")
+	if err != nil {
+		return err
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
 
-	return nil // Simulated success
+	for _, name := range names {
+		sqlStmt := fmt.Sprintf("DROP TABLE IF EXISTS %s;", name)
+		fmt.Printf("[SQLite] Executing: %s\n", sqlStmt)
+		if _, err := o.exec.Exec(sqlStmt); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // DropTable executes SQLite-specific SQL to drop a table.
-func (s *SQLiteSchema) DropTable(name string) error {
+func (o *sqliteOps) DropTable(name string) error {
 	sqlStmt := fmt.Sprintf("DROP TABLE IF EXISTS %s;", name)
 
 	fmt.Printf("[SQLite] Executing: %s\n", sqlStmt)
 
-	// In a real application, you would execute:
-	// _, err := s.DB.Exec(sqlStmt)
-	// return err
+	_, err := o.exec.Exec(sqlStmt)
+	return err
+}
+
+// AddColumn adds the column(s) described by build to an existing table.
+func (o *sqliteOps) AddColumn(table string, build func(*raptor.Blueprint)) error {
+	bp := raptor.NewBlueprint(table)
+	build(bp)
 
-	return nil // Simulated success
+	for _, col := range bp.Columns {
+		sqlStmt := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", table, sqliteColumnSQL(col))
+		fmt.Printf("[SQLite] Executing: %s\n", sqlStmt)
+		if _, err := o.exec.Exec(sqlStmt); err != nil {
+			return err
+		}
+	}
+	return nil
 }
+
+// DropColumn removes a single column from an existing table. Requires
+// SQLite 3.35+, which supports ALTER TABLE ... DROP COLUMN directly.
+func (o *sqliteOps) DropColumn(table, column string) error {
+	sqlStmt := fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", table, column)
+	fmt.Printf("[SQLite] Executing: %s\n", sqlStmt)
+	_, err := o.exec.Exec(sqlStmt)
+	return err
+}
+
+// RenameColumn renames a single column on an existing table. Requires
+// SQLite 3.25+, which supports ALTER TABLE ... RENAME COLUMN directly.
+func (o *sqliteOps) RenameColumn(table, oldName, newName string) error {
+	sqlStmt := fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", table, oldName, newName)
+	fmt.Printf("[SQLite] Executing: %s\n", sqlStmt)
+	_, err := o.exec.Exec(sqlStmt)
+	return err
+}
+
+// RenameTable renames an existing table.
+func (o *sqliteOps) RenameTable(oldName, newName string) error {
+	sqlStmt := fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", oldName, newName)
+	fmt.Printf("[SQLite] Executing: %s\n", sqlStmt)
+	_, err := o.exec.Exec(sqlStmt)
+	return err
+}
+
+// AddIndex adds the index(es) described by build to an existing table.
+func (o *sqliteOps) AddIndex(table string, build func(*raptor.Blueprint)) error {
+	bp := raptor.NewBlueprint(table)
+	build(bp)
+
+	for _, idx := range bp.Indexes {
+		sqlStmt := sqliteIndexSQL(table, idx)
+		fmt.Printf("[SQLite] Executing: %s\n", sqlStmt)
+		if _, err := o.exec.Exec(sqlStmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DropIndex removes a named index from an existing table.
+func (o *sqliteOps) DropIndex(table, indexName string) error {
+	sqlStmt := fmt.Sprintf("DROP INDEX IF EXISTS %s;", indexName)
+	fmt.Printf("[SQLite] Executing: %s\n", sqlStmt)
+	_, err := o.exec.Exec(sqlStmt)
+	return err
+}
+
+// HasMigrationsTable reports whether raptor_migrations already exists.
+func (o *sqliteOps) HasMigrationsTable() (bool, error) {
+	row := o.exec.QueryRow(
+		"SELECT name FROM sqlite_master WHERE type = 'table' AND name = ?",
+		migrationsTableSQLite,
+	)
+	var name string
+	if err := row.Scan(&name); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateMigrationsTable creates the raptor_migrations history table.
+func (o *sqliteOps) CreateMigrationsTable() error {
+	sqlStmt := fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL UNIQUE, batch INTEGER NOT NULL, applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP);",
+		migrationsTableSQLite,
+	)
+
+	fmt.Printf("[SQLite] Executing: %s\n", sqlStmt)
+
+	_, err := o.exec.Exec(sqlStmt)
+	return err
+}
+
+// InsertMigration records a migration as applied in the given batch.
+func (o *sqliteOps) InsertMigration(name string, batch int) error {
+	sqlStmt := fmt.Sprintf("INSERT INTO %s (name, batch) VALUES (?, ?);", migrationsTableSQLite)
+	_, err := o.exec.Exec(sqlStmt, name, batch)
+	return err
+}
+
+// DeleteMigration removes a migration's history row, used on rollback.
+func (o *sqliteOps) DeleteMigration(name string) error {
+	sqlStmt := fmt.Sprintf("DELETE FROM %s WHERE name = ?;", migrationsTableSQLite)
+	_, err := o.exec.Exec(sqlStmt, name)
+	return err
+}
+
+// GetRanMigrations returns every recorded migration, ordered by id.
+func (o *sqliteOps) GetRanMigrations() ([]raptor.MigrationRecord, error) {
+	sqlStmt := fmt.Sprintf("SELECT id, name, batch, applied_at FROM %s ORDER BY id;", migrationsTableSQLite)
+	rows, err := o.exec.Query(sqlStmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []raptor.MigrationRecord{}
+	for rows.Next() {
+		var rec raptor.MigrationRecord
+		if err := rows.Scan(&rec.ID, &rec.Name, &rec.Batch, &rec.AppliedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+// CreateVersionedView is not supported by SQLite: raptor's zero-downtime
+// expand/contract migrations currently depend on Postgres views and triggers.
+func (o *sqliteOps) CreateVersionedView(schema, version string, mapping map[string]string) error {
+	return raptor.ErrUnsupported
+}
+
+// DropVersionedView is not supported by SQLite; see CreateVersionedView.
+func (o *sqliteOps) DropVersionedView(schema, version string) error {
+	return raptor.ErrUnsupported
+}
+
+// BeginVersionedMigration is not supported by SQLite; see CreateVersionedView.
+func (o *sqliteOps) BeginVersionedMigration(name string) error {
+	return raptor.ErrUnsupported
+}
+
+// CompleteVersionedMigration is not supported by SQLite; see CreateVersionedView.
+func (o *sqliteOps) CompleteVersionedMigration(name string) error {
+	return raptor.ErrUnsupported
+}
+
+// RollbackVersionedMigration is not supported by SQLite; see CreateVersionedView.
+func (o *sqliteOps) RollbackVersionedMigration(name string) error {
+	return raptor.ErrUnsupported
+}
+
+// ActiveVersionedMigration is not supported by SQLite; see CreateVersionedView.
+func (o *sqliteOps) ActiveVersionedMigration() (*raptor.VersionedMigrationState, error) {
+	return nil, raptor.ErrUnsupported
+}
+
+// SQLiteSchema implements the raptor.Schema interface for SQLite databases.
+// It requires a standard sql.DB connection.
+type SQLiteSchema struct {
+	DB *sql.DB
+	sqliteOps
+}
+
+// NewSQLiteSchema creates a SQLiteSchema backed by db.
+func NewSQLiteSchema(db *sql.DB) *SQLiteSchema {
+	return &SQLiteSchema{DB: db, sqliteOps: sqliteOps{exec: db}}
+}
+
+// Ensure SQLiteSchema satisfies the raptor.Schema and raptor.TxSchema
+// interfaces at compile time. SQLite DDL is transactional, so it can safely
+// participate in Migrator's UseTransaction mode.
+var _ raptor.Schema = (*SQLiteSchema)(nil)
+var _ raptor.TxSchema = (*SQLiteSchema)(nil)
+
+// BeginTx starts a transaction on the underlying connection.
+func (s *SQLiteSchema) BeginTx() (*sql.Tx, error) {
+	return s.DB.Begin()
+}
+
+// WithTx returns a Schema whose operations run against tx instead of DB.
+func (s *SQLiteSchema) WithTx(tx *sql.Tx) raptor.Schema {
+	return NewSQLiteTxSchema(tx)
+}
+
+// SQLiteTxSchema is the transaction-scoped counterpart of SQLiteSchema,
+// returned by SQLiteSchema.WithTx so a migration's Up/Down call and its
+// history-row write share one *sql.Tx.
+type SQLiteTxSchema struct {
+	Tx *sql.Tx
+	sqliteOps
+}
+
+// NewSQLiteTxSchema creates a SQLiteTxSchema backed by tx.
+func NewSQLiteTxSchema(tx *sql.Tx) *SQLiteTxSchema {
+	return &SQLiteTxSchema{Tx: tx, sqliteOps: sqliteOps{exec: tx}}
+}
+
+// Ensure SQLiteTxSchema satisfies the raptor.Schema interface at compile time.
+var _ raptor.Schema = (*SQLiteTxSchema)(nil)
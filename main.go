@@ -1,13 +1,13 @@
 package main
 
 import (
-	"fmt"
-	"log"
 	"os"
 
 	// 1. Import the core raptor package
 	raptor "github.com/mrhoseah/raptor/core"
-	// 2. Import the package containing your migration files
+	// 2. Import the CLI subsystem that dispatches subcommands to a Migrator
+	"github.com/mrhoseah/raptor/cli"
+	// 3. Import the package containing your migration files
 	"github.com/mrhoseah/raptor/migrations"
 )
 
@@ -23,6 +23,7 @@ func main() {
 	// --- Migrator Initialization (The Plug-and-Play Step) ---
 
 	allMigrations := GetMigrations()
+	cfg := cli.LoadConfig()
 
 	// A. Simulated Usage (for demonstration and testing)
 	mgr := raptor.NewSimulatedMigrator(allMigrations)
@@ -30,39 +31,14 @@ func main() {
 	// B. Real Database Usage (for SQLite, MySQL, Postgres)
 	/*
 		// Example for SQLite (requires a connection and a concrete Schema implementation)
-		dbConn, err := sql.Open("sqlite3", "./migrations.db")
+		dbConn, err := sql.Open("sqlite3", cfg.DSN)
 		if err != nil {
 			log.Fatalf("Could not connect to database: %v", err)
 		}
-		// Assuming you have a concrete 'SQLiteSchema' struct that implements raptor.Schema
-		sqliteSchema := drivers.NewSQLiteSchema(dbConn)
-		mgr := raptor.NewMigrator(allMigrations, sqliteSchema)
+		mgr := raptor.NewMigrator(allMigrations, drivers.NewSQLiteSchema(dbConn))
 	*/
 
 	// --- Command Execution ---
 
-	// Determine command from arguments (simulate artisan/cli tool)
-	command := "status"
-	if len(os.Args) > 1 {
-		command = os.Args[1]
-	}
-
-	log.Printf("Running command: %s", command)
-
-	var err error
-	switch command {
-	case "migrate":
-		err = mgr.Migrate()
-	case "rollback":
-		err = mgr.Rollback()
-	case "status":
-		mgr.Status()
-	default:
-		fmt.Printf("Unknown command: %s. Available commands: migrate, rollback, status\n", command)
-		os.Exit(1)
-	}
-
-	if err != nil {
-		log.Fatalf("Migration failed: %v", err)
-	}
+	os.Exit(cli.Run(mgr, cfg, os.Args[1:]))
 }
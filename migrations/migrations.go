@@ -18,8 +18,12 @@ func (m *CreateUsersTable) Name() string {
 // Up defines the steps to apply this migration.
 func (m *CreateUsersTable) Up(s raptor.Schema) error {
 	log.Println("Running UP for 001_create_users_table")
-	// Using generic column names for demonstration.
-	return s.CreateTable("users", []string{"id", "email", "password", "created_at"})
+	return s.CreateTable("users", func(bp *raptor.Blueprint) {
+		bp.Integer("id").AutoIncrement().Primary()
+		bp.String("email", 255).NotNull().Unique()
+		bp.String("password", 255).NotNull()
+		bp.Timestamp("created_at").NotNull().Default("CURRENT_TIMESTAMP")
+	})
 }
 
 // Down defines the steps to reverse this migration (rollback).